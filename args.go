@@ -0,0 +1,112 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/NeriusZar/pokedexcli/internal/query"
+)
+
+// commandArgs are the arguments of a command split into positional
+// arguments and --flags.
+type commandArgs struct {
+	positional []string
+	flags      map[string]string
+}
+
+// parseArgs splits a into positional arguments and flags. spec lists the
+// accepted flags, mapped to whether they take a value, given either as the
+// next argument or after "=". Flags without a value are set to "true".
+func parseArgs(a []string, spec map[string]bool) (commandArgs, error) {
+	args := commandArgs{positional: []string{}, flags: map[string]string{}}
+
+	for i := 0; i < len(a); i++ {
+		name, found := strings.CutPrefix(a[i], "--")
+		if !found {
+			args.positional = append(args.positional, a[i])
+			continue
+		}
+
+		name, value, hasValue := strings.Cut(name, "=")
+		takesValue, ok := spec[name]
+		if !ok {
+			return commandArgs{}, usageError(fmt.Sprintf("--%s is not a known flag", name))
+		}
+
+		switch {
+		case !takesValue && hasValue:
+			return commandArgs{}, usageError(fmt.Sprintf("--%s does not take a value", name))
+		case !takesValue:
+			value = "true"
+		case !hasValue:
+			if i+1 >= len(a) {
+				return commandArgs{}, usageError(fmt.Sprintf("--%s needs a value", name))
+			}
+			i++
+			value = a[i]
+		}
+		args.flags[name] = value
+	}
+
+	return args, nil
+}
+
+func (a commandArgs) has(name string) bool {
+	_, ok := a.flags[name]
+	return ok
+}
+
+func (a commandArgs) value(name string) string {
+	return a.flags[name]
+}
+
+// queryFlags are the flags of commands that filter and sort pokemons:
+// --sort, --type, --search, --where with comma separated conditions, and
+// --min-<field> and --max-<field> for every number field.
+func queryFlags() map[string]bool {
+	flags := map[string]bool{"sort": true, "type": true, "search": true, "where": true}
+	for _, field := range query.NumberFields() {
+		flags["min-"+field] = true
+		flags["max-"+field] = true
+	}
+
+	return flags
+}
+
+// parseQuery builds the query described by the flags of queryFlags.
+func parseQuery(args commandArgs) (query.Query, error) {
+	var conditions []string
+	if args.has("type") {
+		conditions = append(conditions, "type="+args.value("type"))
+	}
+	if args.has("search") {
+		conditions = append(conditions, "name~"+args.value("search"))
+	}
+	for _, field := range query.NumberFields() {
+		if args.has("min-" + field) {
+			conditions = append(conditions, field+">="+args.value("min-"+field))
+		}
+		if args.has("max-" + field) {
+			conditions = append(conditions, field+"<="+args.value("max-"+field))
+		}
+	}
+	if args.has("where") {
+		conditions = append(conditions, strings.Split(args.value("where"), ",")...)
+	}
+
+	q := query.Query{Sort: args.value("sort")}
+	if q.Sort != "" {
+		if err := query.ValidateSort(q.Sort); err != nil {
+			return query.Query{}, usageError(err.Error())
+		}
+	}
+	for _, s := range conditions {
+		condition, err := query.ParseCondition(strings.TrimSpace(s))
+		if err != nil {
+			return query.Query{}, usageError(err.Error())
+		}
+		q.Conditions = append(q.Conditions, condition)
+	}
+
+	return q, nil
+}
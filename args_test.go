@@ -0,0 +1,74 @@
+package main
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseArgs(t *testing.T) {
+	spec := map[string]bool{"version": true, "detailed": false}
+
+	cases := []struct {
+		name       string
+		input      []string
+		positional []string
+		flags      map[string]string
+		wantErr    bool
+	}{
+		{
+			name:       "positional only",
+			input:      []string{"canalave-city-area"},
+			positional: []string{"canalave-city-area"},
+			flags:      map[string]string{},
+		},
+		{
+			name:       "flags after positional arguments",
+			input:      []string{"canalave-city-area", "--version", "red", "--detailed"},
+			positional: []string{"canalave-city-area"},
+			flags:      map[string]string{"version": "red", "detailed": "true"},
+		},
+		{
+			name:       "value after equals sign",
+			input:      []string{"--version=red", "canalave-city-area"},
+			positional: []string{"canalave-city-area"},
+			flags:      map[string]string{"version": "red"},
+		},
+		{
+			name:    "unknown flag",
+			input:   []string{"--verbose"},
+			wantErr: true,
+		},
+		{
+			name:    "missing value",
+			input:   []string{"--version"},
+			wantErr: true,
+		},
+		{
+			name:    "value for a switch",
+			input:   []string{"--detailed=yes"},
+			wantErr: true,
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			args, err := parseArgs(c.input, spec)
+			if c.wantErr {
+				if err == nil {
+					t.Error("expected an error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if !reflect.DeepEqual(args.positional, c.positional) {
+				t.Errorf("expected positional %v, got %v", c.positional, args.positional)
+			}
+			if !reflect.DeepEqual(args.flags, c.flags) {
+				t.Errorf("expected flags %v, got %v", c.flags, args.flags)
+			}
+		})
+	}
+}
@@ -1,13 +1,28 @@
 package main
 
 import (
+	"bufio"
 	"fmt"
+	"io"
 	"math/rand/v2"
 	"os"
+	"slices"
+	"sort"
+	"strings"
+	"time"
 
 	"github.com/NeriusZar/pokedexcli/internal/api"
+	"github.com/NeriusZar/pokedexcli/internal/capture"
+	"github.com/NeriusZar/pokedexcli/internal/encounter"
+	"github.com/NeriusZar/pokedexcli/internal/leveling"
 	"github.com/NeriusZar/pokedexcli/internal/models"
+	"github.com/NeriusZar/pokedexcli/internal/output"
 	"github.com/NeriusZar/pokedexcli/internal/pokedex"
+	"github.com/NeriusZar/pokedexcli/internal/query"
+	"github.com/NeriusZar/pokedexcli/internal/snapshot"
+	"github.com/NeriusZar/pokedexcli/internal/sprite"
+	"github.com/NeriusZar/pokedexcli/internal/state"
+	"github.com/NeriusZar/pokedexcli/internal/storage"
 )
 
 type CliCommand struct {
@@ -18,19 +33,117 @@ type CliCommand struct {
 
 type config struct {
 	pagination models.Pagination
-	api        api.PokeApi
-	pokedex    pokedex.Pokedex
+	// explored is the last explored area, where pokemons can be caught
+	// until the player travels somewhere.
+	explored *exploreDocument
+	// wild is the pokemon met by the last encounter, caught at its level.
+	wild        *encounter.Wild
+	state       state.State
+	statePath   string
+	api         api.Client
+	pokedex     pokedex.Pokedex
+	snapshotDir string
+	out         io.Writer
+	// in reads the answers to the questions commands ask, from the input
+	// the commands come from.
+	in          *bufio.Scanner
+	format      output.Format
+	rng         *rand.Rand
+	gameVersion string
+	// now tells the time pokemons are caught at.
+	now func() time.Time
+	// strictEncounters only allows catching the pokemon met by the last
+	// encounter.
+	strictEncounters bool
+	// spriteMode is how sprites are coloured, never Auto.
+	spriteMode sprite.Mode
+	// bold highlights in bold rather than with a star, when the output is
+	// a terminal with colours.
+	bold bool
 }
 
-func NewConfig() config {
+type options struct {
+	offline          bool
+	snapshotDir      string
+	apiUrl           string
+	format           output.Format
+	seed             uint64
+	gameVersion      string
+	strictEncounters bool
+	spriteMode       sprite.Mode
+}
+
+func NewConfig(opts options) (config, error) {
+	path, err := pokedex.DefaultPath()
+	if err != nil {
+		return config{}, err
+	}
+
+	p, err := pokedex.Open(path)
+	if err != nil {
+		return config{}, err
+	}
+
+	statePath, err := state.DefaultPath()
+	if err != nil {
+		return config{}, err
+	}
+
+	st, err := state.Load(statePath)
+	if err != nil {
+		return config{}, err
+	}
+
+	cacheDir, err := storage.CacheDir()
+	if err != nil {
+		return config{}, err
+	}
+
+	spriteMode := opts.spriteMode
+	if spriteMode == sprite.Auto || spriteMode == "" {
+		spriteMode = sprite.DetectMode(isTerminal(os.Stdout), os.Getenv)
+	}
+
+	snapshotDir := opts.snapshotDir
+	if snapshotDir == "" {
+		snapshotDir, err = snapshot.DefaultDir()
+		if err != nil {
+			return config{}, err
+		}
+	}
+
 	return config{
 		pagination: models.Pagination{},
-		api:        api.NewPokeApi(),
-		pokedex:    pokedex.NewPokedex(),
-	}
+		state:      st,
+		statePath:  statePath,
+		api: api.NewPokeApi(api.Options{
+			BaseUrl:     opts.apiUrl,
+			CacheDir:    cacheDir,
+			Offline:     opts.offline,
+			SnapshotDir: snapshotDir,
+		}),
+		pokedex:          p,
+		snapshotDir:      snapshotDir,
+		out:              os.Stdout,
+		format:           opts.format,
+		rng:              newRand(opts.seed),
+		gameVersion:      opts.gameVersion,
+		now:              time.Now,
+		strictEncounters: opts.strictEncounters,
+		spriteMode:       spriteMode,
+		bold:             sprite.DetectMode(isTerminal(os.Stdout), os.Getenv) != sprite.ASCII,
+	}, nil
 }
 
-const difficultyConf = 40
+// newRand returns a random source seeded with seed, or with a random seed
+// when seed is 0.
+func newRand(seed uint64) *rand.Rand {
+	if seed == 0 {
+		return rand.New(rand.NewPCG(rand.Uint64(), rand.Uint64()))
+	}
+
+	return rand.New(rand.NewPCG(seed, seed))
+}
 
 func getCommands() map[string]CliCommand {
 	return map[string]CliCommand{
@@ -56,41 +169,243 @@ func getCommands() map[string]CliCommand {
 		},
 		"explore": {
 			name:        "explore",
-			description: "Takes location argument and lists all the Pokemons located there.",
+			description: "Lists all the Pokemons in the current location, or in the given location area. Flags: --version, --method, --sort chance|name, --detailed.",
 			callback:    explore,
 		},
+		"encounter": {
+			name:        "encounter",
+			description: "Takes an optional encounter method and game version, and meets a wild pokemon in the current area.",
+			callback:    encounterCmd,
+		},
+		"walk": {
+			name:        "walk",
+			description: "Takes an optional game version, and walks in the grass of the current area.",
+			callback:    encounterBy(encounter.Walk),
+		},
+		"fish": {
+			name:        "fish",
+			description: "Takes an optional game version, and fishes in the current area.",
+			callback:    encounterBy(encounter.Fish),
+		},
+		"surf": {
+			name:        "surf",
+			description: "Takes an optional game version, and surfs in the current area.",
+			callback:    encounterBy(encounter.Surf),
+		},
+		"bag": {
+			name:        "bag",
+			description: "Lists your money and the items in your bag.",
+			callback:    bag,
+		},
+		"buy": {
+			name:        "buy",
+			description: "Takes an item and an optional quantity, and buys them.",
+			callback:    buy,
+		},
+		"use": {
+			name:        "use",
+			description: "Takes an item and a pokemon, and uses the item on the pokemon.",
+			callback:    use,
+		},
+		"travel": {
+			name:        "travel",
+			description: "Takes location area argument and travels there.",
+			callback:    travel,
+		},
 		"catch": {
 			name:        "catch",
-			description: "Takes name of pokemon and attempts to catch the pokemon.",
+			description: "Takes name of pokemon in the current area and an optional ball, and attempts to catch the pokemon.",
 			callback:    catch,
 		},
 		"inspect": {
 			name:        "inspect",
-			description: "Shows details of caught Pokemon",
+			description: "Shows details of caught Pokemon. --sprite draws it, --shiny and --back pick another sprite.",
 			callback:    inspect,
 		},
 		"pokedex": {
 			name:        "pokedex",
-			description: "Displays the names of all caugth pokemons",
+			description: "Displays the caught pokemons by national dex ID. Flags: --sort id|name|weight|height|<stat> (-<field> for descending), --type, --search, --min-<stat>, --max-<stat>, --where hp>=50,name~char",
 			callback:    pokedexCmd,
 		},
+		"train": {
+			name:        "train",
+			description: "Takes name of caught pokemon and trains it to gain experience.",
+			callback:    train,
+		},
+		"release": {
+			name:        "release",
+			description: "Takes a caught pokemon and releases it after confirmation, or right away with --yes.",
+			callback:    release,
+		},
+		"nickname": {
+			name:        "nickname",
+			description: "Takes a caught pokemon and a nickname, usable wherever a pokemon name is.",
+			callback:    nickname,
+		},
+		"favorite": {
+			name:        "favorite",
+			description: "Takes a caught pokemon and marks it as a favorite, or unmarks it.",
+			callback:    favorite,
+		},
+		"battle": {
+			name:        "battle",
+			description: "Takes your pokemon and an opponent and lets them battle.",
+			callback:    battleCmd,
+		},
+		"weaken": {
+			name:        "weaken",
+			description: "Takes your pokemon and lets it strike the wild pokemon you met once, so it is easier to catch.",
+			callback:    weaken,
+		},
+		"matchup": {
+			name:        "matchup",
+			description: "Takes name of pokemon and shows its type weaknesses, resistances and your best counters.",
+			callback:    matchup,
+		},
+		"evolutions": {
+			name:        "evolutions",
+			description: "Takes name of pokemon and shows its evolution chain.",
+			callback:    evolutions,
+		},
+		"evolve": {
+			name:        "evolve",
+			description: "Takes name of caught pokemon and an optional item, and evolves it when it can.",
+			callback:    evolve,
+		},
+		"compare": {
+			name:        "compare",
+			description: "Takes two or more pokemons, caught or not, and lays their base stats side by side, marking the best ones",
+			callback:    compare,
+		},
+		"ability": {
+			name:        "ability",
+			description: "Takes an ability name and shows its effect, the pokemons that can have it and which of yours can",
+			callback:    ability,
+		},
+		"move": {
+			name:        "move",
+			description: "Takes a move name and shows its type, power, accuracy, PP and effect",
+			callback:    move,
+		},
+		"moves": {
+			name:        "moves",
+			description: "Takes a pokemon name and lists the moves it can learn, sorted by level. Filter by --version-group and --method, --details shows every move.",
+			callback:    moves,
+		},
+		"lookup": {
+			name:        "lookup",
+			description: "Takes any pokemon name or national dex ID and shows its details. Sections: --stats, --abilities, --held-items, --forms, --moves (filtered by --version-group and --method), --game-indices, --cries, --sprite (--shiny, --back).",
+			callback:    lookup,
+		},
+		"progress": {
+			name:        "progress",
+			description: "Shows how many pokemons you have seen and caught, by generation, region and type.",
+			callback:    progressCmd,
+		},
+		"save": {
+			name:        "save",
+			description: "Saves the Pokedex. Takes an optional file path to export it to.",
+			callback:    save,
+		},
+		"load": {
+			name:        "load",
+			description: "Takes a file path and replaces the Pokedex with the one stored there.",
+			callback:    load,
+		},
+		"snapshot": {
+			name:        "snapshot",
+			description: "Records the visited areas and pokemons for offline use. Takes an optional directory.",
+			callback:    snapshotCmd,
+		},
 	}
 }
 
+type commandInfo struct {
+	Name        string `json:"name"`
+	Description string `json:"description"`
+}
+
+type areasDocument struct {
+	Areas    []models.Area `json:"areas"`
+	Next     *string       `json:"next"`
+	Previous *string       `json:"previous"`
+}
+
+type exploreDocument struct {
+	Area        string                       `json:"area"`
+	Pokemons    []models.PokemonShortInfo    `json:"pokemons"`
+	Encounters  []models.Encounter           `json:"encounters,omitempty"`
+	MethodRates []models.EncounterMethodRate `json:"method_rates,omitempty"`
+}
+
+type catchDocument struct {
+	Pokemon string                `json:"pokemon"`
+	Caught  bool                  `json:"caught"`
+	Shakes  int                   `json:"shakes"`
+	Details *models.CaughtPokemon `json:"details,omitempty"`
+	// Experience lists what the rest of the Pokedex gained from the catch.
+	Experience []experienceGain `json:"experience,omitempty"`
+	// HeldItems lists the items the pokemon was holding, now in the bag.
+	HeldItems []string `json:"held_items,omitempty"`
+}
+
+type inspectDocument struct {
+	models.CaughtPokemon
+	ExperienceToNextLevel int            `json:"experience_to_next_level"`
+	ComputedStats         map[string]int `json:"computed_stats"`
+	BaseStatTotal         int            `json:"base_stat_total"`
+}
+
+type pokedexDocument struct {
+	Species  []speciesCount         `json:"species"`
+	Pokemons []models.CaughtPokemon `json:"pokemons"`
+}
+
+// speciesCount is how many pokemons of a species were caught, and their
+// instance IDs.
+type speciesCount struct {
+	Species string `json:"species"`
+	Count   int    `json:"count"`
+	IDs     []int  `json:"ids"`
+	labels  []string
+}
+
+type fileDocument struct {
+	Path     string `json:"path"`
+	Pokemons int    `json:"pokemons"`
+}
+
+type snapshotDocument struct {
+	Dir       string `json:"dir"`
+	Responses int    `json:"responses"`
+}
+
 func commandExit(c *config, a ...string) error {
-	fmt.Println("Closing the Pokedex... Goodbye!")
-	os.Exit(0)
-	return nil
+	c.progress("Closing the Pokedex... Goodbye!\n")
+	return errExit
 }
 
 func commandHelp(c *config, a ...string) error {
-	fmt.Println("Welcome to the Pokedex!")
-	fmt.Printf("Usage:\n\n")
+	commands := getCommands()
+	names := make([]string, 0, len(commands))
+	for name := range commands {
+		names = append(names, name)
+	}
+	sort.Strings(names)
 
-	for _, v := range getCommands() {
-		fmt.Printf("%s: %s\n", v.name, v.description)
+	infos := make([]commandInfo, len(names))
+	for i, name := range names {
+		infos[i] = commandInfo{Name: name, Description: commands[name].description}
 	}
-	return nil
+
+	return c.render(infos, func() {
+		fmt.Fprintln(c.out, "Welcome to the Pokedex!")
+		fmt.Fprintf(c.out, "Usage:\n\n")
+
+		for _, v := range infos {
+			fmt.Fprintf(c.out, "%s: %s\n", v.Name, v.Description)
+		}
+	})
 }
 
 func commandMap(c *config, a ...string) error {
@@ -102,16 +417,14 @@ func commandMap(c *config, a ...string) error {
 	c.pagination.Next = pagination.Next
 	c.pagination.Previous = pagination.Previous
 
-	for _, a := range areas {
-		fmt.Println(a.Name)
-	}
-	return nil
+	return c.renderAreas(areas)
 }
 
 func commandMapb(c *config, a ...string) error {
 	if c.pagination.Previous == nil {
-		fmt.Println("you're on the first page")
-		return nil
+		return c.render(areasDocument{Areas: []models.Area{}, Next: c.pagination.Next}, func() {
+			fmt.Fprintln(c.out, "you're on the first page")
+		})
 	}
 
 	areas, pagination, err := c.api.RetrieveAreas(c.pagination.Previous)
@@ -122,98 +435,383 @@ func commandMapb(c *config, a ...string) error {
 	c.pagination.Next = pagination.Next
 	c.pagination.Previous = pagination.Previous
 
-	for _, a := range areas {
-		fmt.Println(a.Name)
+	return c.renderAreas(areas)
+}
+
+func (c *config) renderAreas(areas []models.Area) error {
+	doc := areasDocument{
+		Areas:    areas,
+		Next:     c.pagination.Next,
+		Previous: c.pagination.Previous,
 	}
-	return nil
+
+	return c.render(doc, func() {
+		for _, a := range areas {
+			fmt.Fprintln(c.out, a.Name)
+		}
+	})
 }
 
+var exploreFlags = map[string]bool{"version": true, "method": true, "sort": true, "detailed": false}
+
 func explore(c *config, a ...string) error {
-	if len(a) < 1 {
-		fmt.Println("You didn't provide any areas to explore.")
-		return nil
+	args, err := parseArgs(a, exploreFlags)
+	if err != nil {
+		return err
+	}
+
+	area := c.state.Location
+	if len(args.positional) > 0 {
+		area = args.positional[0]
+	}
+	if area == "" {
+		return usageError("You didn't provide any areas to explore.")
 	}
-	area := a[0]
 
-	fmt.Printf("Exploring %s...\n", area)
+	sortBy := args.value("sort")
+	if sortBy != "" && sortBy != "chance" && sortBy != "name" {
+		return usageError(fmt.Sprintf("can't sort by %s, use chance or name", sortBy))
+	}
+
+	c.progress("Exploring %s...\n", area)
 
 	pokemons, err := c.api.RetrievePokemonsInArea(area)
 	if err != nil {
 		return err
 	}
 
-	fmt.Println("Found Pokemon:")
-	for _, p := range pokemons {
-		fmt.Printf(" - %s\n", p.Name)
+	c.explored = &exploreDocument{Area: area, Pokemons: pokemons}
+
+	names := make([]string, len(pokemons))
+	for i, p := range pokemons {
+		names[i] = p.Name
+	}
+	if err := c.markSeen(names...); err != nil {
+		return err
 	}
 
-	return nil
+	doc := exploreDocument{Area: area, Pokemons: pokemons}
+	detailed := args.has("detailed")
+	if detailed || args.has("version") || args.has("method") || sortBy != "" {
+		details, err := c.api.GetAreaEncounters(area)
+		if err != nil {
+			return err
+		}
+
+		doc = encounterTable(details, pokemons, args.value("version"), args.value("method"), sortBy)
+		if !detailed {
+			doc.Encounters, doc.MethodRates = nil, nil
+		}
+	}
+
+	return c.render(doc, func() {
+		fmt.Fprintln(c.out, "Found Pokemon:")
+		if !detailed {
+			for _, p := range doc.Pokemons {
+				fmt.Fprintf(c.out, " - %s\n", p.Name)
+			}
+			return
+		}
+
+		printEncounterTable(c.out, doc)
+	})
 }
 
 func catch(c *config, a ...string) error {
 	if len(a) < 1 {
-		fmt.Println("You didn't provide any pokemon to catch")
-		return nil
+		return usageError("You didn't provide any pokemon to catch")
 	}
 
 	name := a[0]
 
-	fmt.Printf("Throwing a Pokeball at %s...\n", name)
+	ballName := capture.DefaultBall
+	if len(a) > 1 {
+		ballName = a[1]
+	}
+	ball, ok := capture.Balls[ballName]
+	if !ok {
+		return usageError(fmt.Sprintf("%s is not a ball you can throw", ballName))
+	}
+
+	area, present, err := c.catchArea()
+	if err != nil {
+		return err
+	}
+	if !slices.ContainsFunc(present, func(p models.PokemonShortInfo) bool { return p.Name == name }) {
+		return &commandError{code: "not_here", message: fmt.Sprintf("%s can't be found in %s", name, area)}
+	}
+
+	level, hpFraction := catchLevel, 1.0
+	if c.wild != nil && c.wild.Pokemon == name && c.wild.Area == area {
+		level = c.wild.Level
+		if c.wild.MaxHP > 0 {
+			hpFraction = float64(c.wild.HP) / float64(c.wild.MaxHP)
+		}
+	} else if c.strictEncounters {
+		return &commandError{code: "not_here", message: fmt.Sprintf("%s has not appeared, use encounter first", name)}
+	}
+
+	if c.state.Count(ball.Name) == 0 {
+		return &commandError{code: "not_in_bag", message: fmt.Sprintf("you don't have any %s left", ball.Label)}
+	}
 
 	pokemon, err := c.api.GetPokemonDetails(name)
 	if err != nil {
 		return err
 	}
 
-	if rand.IntN(pokemon.BaseExperience) > difficultyConf {
-		fmt.Printf("%s escaped!\n", name)
-		return nil
+	species, err := c.api.GetPokemonSpecies(pokemon.SpeciesName())
+	if err != nil {
+		return err
 	}
 
-	c.pokedex.Add(pokemon)
+	// The ball is only used up once nothing can stop the throw.
+	c.state.TakeItem(ball.Name)
+	if err := c.saveState(); err != nil {
+		return err
+	}
 
-	fmt.Printf("%s was caught!\n", name)
+	c.progress("Throwing a %s at %s...\n", ball.Label, name)
 
-	return nil
+	result := capture.Attempt(c.rng, species.CaptureRate, ball, hpFraction)
+	if !result.Caught {
+		return c.render(catchDocument{Pokemon: name, Shakes: result.Shakes}, func() {
+			fmt.Fprintf(c.out, "%s escaped!\n", name)
+		})
+	}
+
+	caught := newCaughtPokemon(pokemon, species, level)
+	caught.CaughtAt = c.now()
+	caught.CaughtIn = area
+	c.wild = nil
+
+	party := c.pokedex.GetAll()
+
+	caught, err = c.pokedex.Add(caught)
+	if err != nil {
+		return err
+	}
+
+	doc := catchDocument{Pokemon: name, Caught: true, Shakes: result.Shakes, Details: &caught}
+	for _, held := range pokemon.HeldItems {
+		if c.rng.IntN(100) < held.Rarity(c.gameVersion) {
+			c.state.AddItem(held.Name, 1)
+			doc.HeldItems = append(doc.HeldItems, held.Name)
+		}
+	}
+	if len(doc.HeldItems) > 0 {
+		if err := c.saveState(); err != nil {
+			return err
+		}
+	}
+
+	yield := leveling.ExperienceYield(pokemon.BaseExperience, level)
+	for _, member := range party {
+		if member.Level >= leveling.MaxLevel {
+			continue
+		}
+
+		gain, err := c.gainExperience(member, yield)
+		if err != nil {
+			return err
+		}
+		doc.Experience = append(doc.Experience, gain)
+	}
+
+	return c.render(doc, func() {
+		fmt.Fprintf(c.out, "%s was caught!\n", name)
+		for _, item := range doc.HeldItems {
+			fmt.Fprintf(c.out, "%s was holding %s!\n", name, item)
+		}
+		for _, gain := range doc.Experience {
+			fmt.Fprintln(c.out, gain)
+		}
+	})
 }
 
 func inspect(c *config, a ...string) error {
-	if len(a) < 1 {
-		fmt.Println("You didn't provide pokemon name")
-		return nil
+	args, err := parseArgs(a, addSpriteFlags(map[string]bool{}))
+	if err != nil {
+		return err
+	}
+	if len(args.positional) < 1 {
+		return usageError("You didn't provide pokemon name")
 	}
 
-	name := a[0]
+	pokemon, err := c.find(args.positional[0])
+	if err != nil {
+		return err
+	}
+	art, err := c.sprite(pokemon.Pokemon, args)
+	if err != nil {
+		return err
+	}
 
-	pokemon, ok := c.pokedex.Get(name)
-	if !ok {
-		fmt.Println("you have not caught that pokemon")
-		return nil
+	doc := inspectDocument{
+		CaughtPokemon: pokemon,
+		ComputedStats: map[string]int{},
+		BaseStatTotal: pokemon.BaseStatTotal(),
+	}
+	if pokemon.Level < leveling.MaxLevel {
+		doc.ExperienceToNextLevel = max(0, leveling.ExperienceForLevel(pokemon.GrowthRate, pokemon.Level+1)-pokemon.Experience)
+	}
+	for _, stat := range pokemon.Stats {
+		doc.ComputedStats[stat.Name] = leveling.Stat(stat.Name, stat.BaseStat, pokemon.Level)
 	}
 
-	fmt.Printf("Name: %s\n", pokemon.Name)
-	fmt.Printf("Weight: %d\n", pokemon.Weight)
-	fmt.Printf("Height: %d\n", pokemon.Height)
+	return c.render(doc, func() {
+		fmt.Fprint(c.out, art)
+		fmt.Fprintf(c.out, "Name: %s\n", pokemon.Name)
+		fmt.Fprintf(c.out, "ID: #%d\n", pokemon.InstanceID)
+		if pokemon.Nickname != "" {
+			fmt.Fprintf(c.out, "Nickname: %s\n", pokemon.Nickname)
+		}
+		if pokemon.Favorite {
+			fmt.Fprintln(c.out, "Favorite: yes")
+		}
+		fmt.Fprintf(c.out, "Level: %d\n", pokemon.Level)
+		if pokemon.Level < leveling.MaxLevel {
+			fmt.Fprintf(c.out, "Experience: %d (%d to next level)\n", pokemon.Experience, doc.ExperienceToNextLevel)
+		} else {
+			fmt.Fprintf(c.out, "Experience: %d\n", pokemon.Experience)
+		}
+		fmt.Fprintf(c.out, "Weight: %d\n", pokemon.Weight)
+		fmt.Fprintf(c.out, "Height: %d\n", pokemon.Height)
+		if !pokemon.CaughtAt.IsZero() {
+			fmt.Fprintf(c.out, "Caught: %s in %s\n", pokemon.CaughtAt.Format(time.DateOnly), pokemon.CaughtIn)
+		}
+
+		fmt.Fprintln(c.out, "Stats:")
+		for _, stat := range pokemon.Stats {
+			fmt.Fprintf(c.out, " -%-16s %3d (base %3d) %s\n", stat.Name+":", doc.ComputedStats[stat.Name], stat.BaseStat, statBar(stat.BaseStat))
+		}
+		fmt.Fprintf(c.out, "Base stat total: %d\n", doc.BaseStatTotal)
+
+		fmt.Fprintln(c.out, "Types:")
+		for _, t := range pokemon.Types {
+			fmt.Fprintf(c.out, " - %s\n", t)
+		}
+
+		fmt.Fprintln(c.out, "Abilities:")
+		for _, a := range pokemon.Abilities {
+			fmt.Fprintf(c.out, " - %s%s\n", a.Name, hiddenMark(a.Hidden))
+		}
+	})
+}
 
-	fmt.Println("Stats:")
-	for _, stat := range pokemon.Stats {
-		fmt.Printf(" -%s: %d\n", stat.Name, stat.BaseStat)
+func pokedexCmd(c *config, a ...string) error {
+	args, err := parseArgs(a, queryFlags())
+	if err != nil {
+		return err
+	}
+	q, err := parseQuery(args)
+	if err != nil {
+		return err
+	}
+
+	pokemons := query.Apply(q, c.pokedex.GetAll(), func(p models.CaughtPokemon) models.Pokemon { return p.Pokemon })
+
+	doc := pokedexDocument{Species: []speciesCount{}, Pokemons: pokemons}
+	for _, p := range pokemons {
+		i := slices.IndexFunc(doc.Species, func(s speciesCount) bool { return s.Species == p.Name })
+		if i < 0 {
+			doc.Species = append(doc.Species, speciesCount{Species: p.Name})
+			i = len(doc.Species) - 1
+		}
+		doc.Species[i].Count++
+		doc.Species[i].IDs = append(doc.Species[i].IDs, p.InstanceID)
+		doc.Species[i].labels = append(doc.Species[i].labels, instanceLabel(p))
 	}
 
-	fmt.Println("Types:")
-	for _, t := range pokemon.Types {
-		fmt.Printf(" - %s\n", t)
+	return c.render(doc, func() {
+		fmt.Fprintln(c.out, "Your Pokedex:")
+
+		for _, s := range doc.Species {
+			if s.Count == 1 {
+				fmt.Fprintf(c.out, " - %s (%s)\n", s.Species, s.labels[0])
+				continue
+			}
+			fmt.Fprintf(c.out, " - %s x%d (%s)\n", s.Species, s.Count, strings.Join(s.labels, ", "))
+		}
+	})
+}
+
+// instanceLabel is the instance ID of a caught pokemon, with its nickname and
+// a star for favorites.
+func instanceLabel(p models.CaughtPokemon) string {
+	label := fmt.Sprintf("#%d", p.InstanceID)
+	if p.Nickname != "" {
+		label += " " + p.Nickname
+	}
+	if p.Favorite {
+		label += " ★"
 	}
 
-	return nil
+	return label
 }
 
-func pokedexCmd(c *config, a ...string) error {
-	fmt.Println("Your Pokedex:")
+func save(c *config, a ...string) error {
+	path := c.pokedex.Path()
+	if len(a) > 0 {
+		path = a[0]
+	}
+
+	if path == "" {
+		return usageError("You didn't provide a file to save to")
+	}
+
+	if err := c.pokedex.Save(path); err != nil {
+		return err
+	}
+
+	doc := fileDocument{Path: path, Pokemons: len(c.pokedex.GetAll())}
+	return c.render(doc, func() {
+		fmt.Fprintf(c.out, "Pokedex saved to %s\n", path)
+	})
+}
+
+func load(c *config, a ...string) error {
+	if len(a) < 1 {
+		return usageError("You didn't provide a file to load")
+	}
+
+	path := a[0]
+
+	if err := c.pokedex.Load(path); err != nil {
+		return err
+	}
+
+	doc := fileDocument{Path: path, Pokemons: len(c.pokedex.GetAll())}
+	return c.render(doc, func() {
+		fmt.Fprintf(c.out, "Loaded %d pokemons from %s\n", doc.Pokemons, path)
+	})
+}
+
+func snapshotCmd(c *config, a ...string) error {
+	recorder, ok := c.api.(api.Recorder)
+	if !ok {
+		return &commandError{code: "unsupported", message: "This PokeAPI client can't record snapshots"}
+	}
+
+	dir := c.snapshotDir
+	if len(a) > 0 {
+		dir = a[0]
+	}
 
-	for _, p := range c.pokedex.GetAll() {
-		fmt.Printf(" - %s\n", p.Name)
+	pokemons := c.pokedex.GetAll()
+	names := make([]string, len(pokemons))
+	for i, p := range pokemons {
+		names[i] = p.Name
+	}
+
+	c.progress("Recording snapshot to %s...\n", dir)
+
+	recorded, err := recorder.Snapshot(snapshot.NewStore(dir), names)
+	if err != nil {
+		return err
 	}
 
-	return nil
+	return c.render(snapshotDocument{Dir: dir, Responses: recorded}, func() {
+		fmt.Fprintf(c.out, "Recorded %d responses\n", recorded)
+	})
 }
@@ -0,0 +1,82 @@
+package main
+
+import (
+	"fmt"
+	"slices"
+
+	"github.com/NeriusZar/pokedexcli/internal/models"
+)
+
+type abilityDocument struct {
+	models.AbilityDetails
+	// Caught are the pokemons in the Pokedex that can have the ability.
+	Caught []caughtAbility `json:"caught"`
+}
+
+type caughtAbility struct {
+	InstanceID int    `json:"instance_id"`
+	Name       string `json:"name"`
+	Hidden     bool   `json:"hidden"`
+	label      string
+}
+
+// ability shows what an ability does, the pokemons that can have it and
+// which of the caught ones can.
+func ability(c *config, a ...string) error {
+	if len(a) < 1 {
+		return usageError("You didn't provide ability name")
+	}
+
+	details, err := c.api.GetAbility(a[0])
+	if err != nil {
+		return err
+	}
+
+	caught, err := c.refresh(c.pokedex.GetAll()...)
+	if err != nil {
+		return err
+	}
+
+	doc := abilityDocument{AbilityDetails: details, Caught: []caughtAbility{}}
+	for _, p := range caught {
+		i := slices.IndexFunc(p.Abilities, func(a models.Ability) bool { return a.Name == details.Name })
+		if i < 0 {
+			continue
+		}
+		doc.Caught = append(doc.Caught, caughtAbility{
+			InstanceID: p.InstanceID,
+			Name:       p.Name,
+			Hidden:     p.Abilities[i].Hidden,
+			label:      instanceLabel(p),
+		})
+	}
+
+	return c.render(doc, func() {
+		fmt.Fprintf(c.out, "Name: %s\n", doc.Name)
+		if doc.Effect != "" {
+			fmt.Fprintf(c.out, "Effect: %s\n", doc.Effect)
+		}
+
+		fmt.Fprintln(c.out, "Pokemons:")
+		for _, p := range doc.Pokemons {
+			fmt.Fprintf(c.out, " - %s%s\n", p.Name, hiddenMark(p.Hidden))
+		}
+
+		if len(doc.Caught) == 0 {
+			fmt.Fprintf(c.out, "None of your pokemons can have %s\n", doc.Name)
+			return
+		}
+		fmt.Fprintln(c.out, "Your pokemons:")
+		for _, p := range doc.Caught {
+			fmt.Fprintf(c.out, " - %s (%s)%s\n", p.Name, p.label, hiddenMark(p.Hidden))
+		}
+	})
+}
+
+func hiddenMark(hidden bool) string {
+	if hidden {
+		return " (hidden)"
+	}
+
+	return ""
+}
@@ -0,0 +1,139 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+
+	"github.com/NeriusZar/pokedexcli/internal/battle"
+	"github.com/NeriusZar/pokedexcli/internal/models"
+	"github.com/NeriusZar/pokedexcli/internal/pokedex"
+)
+
+const battleMoves = 4
+
+func battleCmd(c *config, a ...string) error {
+	if len(a) < 2 {
+		return usageError("You need to provide your pokemon and its opponent")
+	}
+
+	mine, err := c.pokedex.Find(a[0])
+	if err != nil {
+		return err
+	}
+
+	other, err := c.pokedex.Find(a[1])
+	if errors.Is(err, pokedex.ErrNotCaught) {
+		pokemon, err := c.api.GetPokemonDetails(a[1])
+		if err != nil {
+			return err
+		}
+		other = models.CaughtPokemon{Pokemon: pokemon, Level: mine.Level}
+	} else if err != nil {
+		return err
+	}
+
+	first, err := c.combatant(mine.Pokemon, mine.Level)
+	if err != nil {
+		return err
+	}
+	second, err := c.combatant(other.Pokemon, other.Level)
+	if err != nil {
+		return err
+	}
+
+	chart, err := c.typeChart(slices.Concat(mine.Types, other.Types))
+	if err != nil {
+		return err
+	}
+
+	c.progress("%s is challenging %s!\n", mine.Name, other.Name)
+
+	result := battle.New(first, second, chart, c.rng).Run(battle.DefaultMaxTurns)
+
+	return c.render(result, func() {
+		for _, e := range result.Log {
+			fmt.Fprintln(c.out, e)
+		}
+		fmt.Fprintf(c.out, "%s won after %d turns!\n", result.Winner, result.Turns)
+	})
+}
+
+// weaken lets a caught pokemon strike the wild pokemon of the last encounter
+// once, so it is easier to catch. A wild pokemon that faints runs away.
+func weaken(c *config, a ...string) error {
+	if len(a) < 1 {
+		return usageError("You need to provide the pokemon that weakens the wild one")
+	}
+	if c.wild == nil {
+		return &commandError{code: "not_here", message: "No wild pokemon has appeared, use encounter first"}
+	}
+
+	mine, err := c.pokedex.Find(a[0])
+	if err != nil {
+		return err
+	}
+
+	wild, err := c.api.GetPokemonDetails(c.wild.Pokemon)
+	if err != nil {
+		return err
+	}
+
+	attacker, err := c.combatant(mine.Pokemon, mine.Level)
+	if err != nil {
+		return err
+	}
+	defender, err := c.combatant(wild, c.wild.Level)
+	if err != nil {
+		return err
+	}
+	defender.HP = c.wild.HP
+
+	chart, err := c.typeChart(wild.Types)
+	if err != nil {
+		return err
+	}
+
+	event := battle.New(attacker, defender, chart, c.rng).Strike()
+	c.wild.HP = event.DefenderHP
+	c.wild.MaxHP = defender.MaxHP()
+	if event.Fainted {
+		c.wild = nil
+	}
+
+	return c.render(event, func() {
+		fmt.Fprintln(c.out, event)
+		if event.Fainted {
+			fmt.Fprintf(c.out, "The wild %s ran away!\n", event.Defender)
+		}
+	})
+}
+
+// combatant picks up to battleMoves random moves the pokemon can learn and
+// fetches their details.
+func (c *config) combatant(pokemon models.Pokemon, level int) (battle.Combatant, error) {
+	if len(pokemon.Moves) == 0 {
+		details, err := c.api.GetPokemonDetails(pokemon.Name)
+		if err != nil {
+			return battle.Combatant{}, err
+		}
+		pokemon.Moves = details.Moves
+	}
+
+	candidates := make([]models.PokemonMove, len(pokemon.Moves))
+	copy(candidates, pokemon.Moves)
+	c.rng.Shuffle(len(candidates), func(i, j int) {
+		candidates[i], candidates[j] = candidates[j], candidates[i]
+	})
+
+	combatant := battle.Combatant{Pokemon: pokemon, Level: level}
+	for _, m := range candidates[:min(battleMoves, len(candidates))] {
+		move, err := c.api.GetMove(m.Name)
+		if err != nil {
+			return battle.Combatant{}, err
+		}
+		combatant.Moves = append(combatant.Moves, move)
+	}
+
+	return combatant, nil
+}
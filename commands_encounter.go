@@ -0,0 +1,85 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/NeriusZar/pokedexcli/internal/encounter"
+)
+
+type encounterDocument struct {
+	encounter.Wild
+}
+
+func encounterCmd(c *config, a ...string) error {
+	method := ""
+	if len(a) > 0 {
+		method = a[0]
+		a = a[1:]
+	}
+
+	return c.encounter(method, a...)
+}
+
+// encounterBy returns the callback of an encounter variant that only meets
+// pokemons with the given method.
+func encounterBy(method string) func(*config, ...string) error {
+	return func(c *config, a ...string) error {
+		return c.encounter(method, a...)
+	}
+}
+
+// encounter rolls a wild pokemon of the current area met with method, in the
+// game version given as argument, by the --game option or, by default, the
+// first version the area has encounters in.
+func (c *config) encounter(method string, a ...string) error {
+	area, err := c.currentArea()
+	if err != nil {
+		return err
+	}
+
+	details, err := c.api.GetAreaEncounters(area)
+	if err != nil {
+		return err
+	}
+
+	version := c.gameVersion
+	if len(a) > 0 {
+		version = a[0]
+	}
+	if version == "" {
+		if versions := encounter.Versions(details.Encounters); len(versions) > 0 {
+			version = versions[0]
+		}
+	}
+
+	wild, ok := encounter.Roll(c.rng, encounter.Filter(details.Encounters, version, method))
+	if !ok {
+		if method == "" {
+			method = "any method"
+		}
+		return &commandError{code: "no_encounter", message: fmt.Sprintf("No pokemons can be met by %s in %s in pokemon %s", method, area, version)}
+	}
+	wild.Area = area
+	c.wild = &wild
+	if err := c.markSeen(wild.Pokemon); err != nil {
+		return err
+	}
+
+	return c.render(encounterDocument{Wild: wild}, func() {
+		fmt.Fprintf(c.out, "A wild %s (level %d) appeared!\n", wild.Pokemon, wild.Level)
+	})
+}
+
+// currentArea returns the current location, or the last explored area when
+// the player has not travelled yet.
+func (c *config) currentArea() (string, error) {
+	if c.state.Location != "" {
+		return c.state.Location, nil
+	}
+
+	if c.explored != nil {
+		return c.explored.Area, nil
+	}
+
+	return "", &commandError{code: "not_here", message: "Travel to or explore an area first"}
+}
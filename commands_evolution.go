@@ -0,0 +1,186 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/NeriusZar/pokedexcli/internal/models"
+)
+
+type evolveDocument struct {
+	Pokemon     string                `json:"pokemon"`
+	Evolved     bool                  `json:"evolved"`
+	EvolvedInto *models.CaughtPokemon `json:"evolved_into,omitempty"`
+	// Requirements lists what is missing for each possible evolution.
+	Requirements map[string]string `json:"requirements,omitempty"`
+}
+
+func evolutions(c *config, a ...string) error {
+	if len(a) < 1 {
+		return usageError("You didn't provide pokemon name")
+	}
+
+	pokemon, err := c.findPokemon(a[0])
+	if err != nil {
+		return err
+	}
+
+	chain, err := c.evolutionChain(pokemon)
+	if err != nil {
+		return err
+	}
+
+	return c.render(chain, func() {
+		fmt.Fprintln(c.out, chain.Chain.Species)
+		printEvolutions(c, chain.Chain, "")
+	})
+}
+
+func printEvolutions(c *config, node models.EvolutionNode, indent string) {
+	for i, next := range node.EvolvesTo {
+		branch, nextIndent := "├─ ", indent+"│  "
+		if i == len(node.EvolvesTo)-1 {
+			branch, nextIndent = "└─ ", indent+"   "
+		}
+
+		details := make([]string, len(next.Details))
+		for j, d := range next.Details {
+			details[j] = d.String()
+		}
+
+		line := indent + branch + next.Species
+		if len(details) > 0 {
+			line += " (" + strings.Join(details, " or ") + ")"
+		}
+		fmt.Fprintln(c.out, line)
+
+		printEvolutions(c, next, nextIndent)
+	}
+}
+
+func evolve(c *config, a ...string) error {
+	if len(a) < 1 {
+		return usageError("You didn't provide pokemon name")
+	}
+
+	ref := a[0]
+	item := ""
+	if len(a) > 1 {
+		item = a[1]
+	}
+
+	pokemon, err := c.pokedex.Find(ref)
+	if err != nil {
+		return err
+	}
+	name := pokemon.Name
+
+	if item != "" && c.state.Count(item) == 0 {
+		return &commandError{code: "not_in_bag", message: fmt.Sprintf("you don't have a %s", item)}
+	}
+
+	chain, err := c.evolutionChain(pokemon.Pokemon)
+	if err != nil {
+		return err
+	}
+
+	node, ok := chain.Chain.Find(pokemon.SpeciesName())
+	if !ok {
+		return fmt.Errorf("%s is missing from its own evolution chain", pokemon.SpeciesName())
+	}
+
+	doc := evolveDocument{Pokemon: name, Requirements: map[string]string{}}
+	for _, next := range node.EvolvesTo {
+		missing := ""
+		usesItem := false
+		for _, detail := range next.Details {
+			missing = unmetRequirement(detail, pokemon, item)
+			if missing == "" {
+				usesItem = detail.Trigger == "use-item"
+				break
+			}
+		}
+
+		if missing != "" {
+			doc.Requirements[next.Species] = missing
+			continue
+		}
+
+		details, err := c.api.GetPokemonDetails(next.Species)
+		if err != nil {
+			return err
+		}
+		evolved := pokemon
+		evolved.Pokemon = details
+		if err := c.pokedex.Replace(evolved); err != nil {
+			return err
+		}
+		if usesItem {
+			c.state.TakeItem(item)
+			if err := c.saveState(); err != nil {
+				return err
+			}
+		}
+
+		doc.Evolved = true
+		doc.EvolvedInto = &evolved
+		doc.Requirements = nil
+		break
+	}
+
+	return c.render(doc, func() {
+		if doc.Evolved {
+			fmt.Fprintf(c.out, "%s evolved into %s!\n", name, doc.EvolvedInto.Name)
+			return
+		}
+		if len(doc.Requirements) == 0 {
+			fmt.Fprintf(c.out, "%s does not evolve\n", name)
+			return
+		}
+
+		fmt.Fprintf(c.out, "%s can't evolve yet:\n", name)
+		for _, next := range node.EvolvesTo {
+			fmt.Fprintf(c.out, " - %s %s\n", next.Species, doc.Requirements[next.Species])
+		}
+	})
+}
+
+// evolutionChain follows the species URL of pokemon to its evolution chain.
+// Pokemons stored before the URL was recorded only know the species name.
+func (c *config) evolutionChain(pokemon models.Pokemon) (models.EvolutionChain, error) {
+	var species models.Species
+	var err error
+	if pokemon.SpeciesUrl != "" {
+		species, err = c.api.GetPokemonSpeciesByUrl(pokemon.SpeciesUrl)
+	} else {
+		species, err = c.api.GetPokemonSpecies(pokemon.SpeciesName())
+	}
+	if err != nil {
+		return models.EvolutionChain{}, err
+	}
+
+	return c.api.GetEvolutionChain(species.EvolutionChainUrl)
+}
+
+// unmetRequirement explains what is missing for detail to let the caught
+// pokemon evolve, or returns "" when nothing is.
+func unmetRequirement(detail models.EvolutionDetail, caught models.CaughtPokemon, item string) string {
+	if len(detail.Conditions) > 0 {
+		return "requires " + strings.Join(detail.Conditions, ", ")
+	}
+
+	switch detail.Trigger {
+	case "level-up":
+		if caught.Level < detail.MinLevel {
+			return fmt.Sprintf("requires level %d", detail.MinLevel)
+		}
+		return ""
+	case "use-item":
+		if item != detail.Item {
+			return "requires using " + detail.Item
+		}
+		return ""
+	default:
+		return "requires " + detail.Trigger
+	}
+}
@@ -0,0 +1,136 @@
+package main
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"strconv"
+
+	"github.com/NeriusZar/pokedexcli/internal/capture"
+	"github.com/NeriusZar/pokedexcli/internal/leveling"
+)
+
+// rareCandy raises the level of a pokemon by one.
+const rareCandy = "rare-candy"
+
+type bagDocument struct {
+	Money int            `json:"money"`
+	Items map[string]int `json:"items"`
+}
+
+type purchaseDocument struct {
+	Item     string `json:"item"`
+	Quantity int    `json:"quantity"`
+	Cost     int    `json:"cost"`
+	Money    int    `json:"money"`
+}
+
+func bag(c *config, a ...string) error {
+	doc := bagDocument{Money: c.state.Money, Items: c.state.Inventory}
+	if doc.Items == nil {
+		doc.Items = map[string]int{}
+	}
+
+	return c.render(doc, func() {
+		fmt.Fprintf(c.out, "Money: %d\n", doc.Money)
+		if len(doc.Items) == 0 {
+			fmt.Fprintln(c.out, "Your bag is empty")
+			return
+		}
+
+		fmt.Fprintln(c.out, "Your bag:")
+		for _, item := range slices.Sorted(maps.Keys(doc.Items)) {
+			fmt.Fprintf(c.out, " - %s x%d\n", item, doc.Items[item])
+		}
+	})
+}
+
+func buy(c *config, a ...string) error {
+	if len(a) < 1 {
+		return usageError("You didn't provide an item to buy")
+	}
+
+	quantity := 1
+	if len(a) > 1 {
+		n, err := strconv.Atoi(a[1])
+		if err != nil || n < 1 {
+			return usageError(fmt.Sprintf("%s is not a quantity", a[1]))
+		}
+		quantity = n
+	}
+
+	item, err := c.api.GetItem(a[0])
+	if err != nil {
+		return err
+	}
+	if item.Cost <= 0 {
+		return &commandError{code: "not_for_sale", message: fmt.Sprintf("%s can't be bought", item.Name)}
+	}
+
+	// Checked before multiplying, so huge quantities can't overflow the cost.
+	if quantity > c.state.Money/item.Cost {
+		return &commandError{code: "not_enough_money", message: fmt.Sprintf("%d %s cost %d each, you only have %d", quantity, item.Name, item.Cost, c.state.Money)}
+	}
+	cost := item.Cost * quantity
+
+	c.state.Money -= cost
+	c.state.AddItem(item.Name, quantity)
+	if err := c.saveState(); err != nil {
+		return err
+	}
+
+	doc := purchaseDocument{Item: item.Name, Quantity: quantity, Cost: cost, Money: c.state.Money}
+	return c.render(doc, func() {
+		fmt.Fprintf(c.out, "Bought %d %s for %d, %d left\n", quantity, item.Name, cost, c.state.Money)
+	})
+}
+
+func use(c *config, a ...string) error {
+	if len(a) < 1 {
+		return usageError("You didn't provide an item to use")
+	}
+
+	name := a[0]
+	if c.state.Count(name) == 0 {
+		return &commandError{code: "not_in_bag", message: fmt.Sprintf("you don't have a %s", name)}
+	}
+	if _, ok := capture.Balls[name]; ok {
+		return usageError(fmt.Sprintf("Throw a %s with catch <pokemon> %s", name, name))
+	}
+
+	item, err := c.api.GetItem(name)
+	if err != nil {
+		return err
+	}
+	if item.Name != rareCandy && item.Category != "evolution" {
+		return &commandError{code: "cant_use", message: fmt.Sprintf("%s can't be used here", name)}
+	}
+
+	if len(a) < 2 {
+		return usageError(fmt.Sprintf("You didn't provide the pokemon to use %s on", name))
+	}
+	if item.Category == "evolution" {
+		return evolve(c, a[1], name)
+	}
+
+	caught, err := c.pokedex.Find(a[1])
+	if err != nil {
+		return err
+	}
+	if caught.Level >= leveling.MaxLevel {
+		return &commandError{code: "max_level", message: fmt.Sprintf("%s is already at level %d", caught.Name, leveling.MaxLevel)}
+	}
+
+	gain, err := c.gainExperience(caught, leveling.ExperienceForLevel(caught.GrowthRate, caught.Level+1)-caught.Experience)
+	if err != nil {
+		return err
+	}
+	c.state.TakeItem(name)
+	if err := c.saveState(); err != nil {
+		return err
+	}
+
+	return c.render(gain, func() {
+		fmt.Fprintln(c.out, gain)
+	})
+}
@@ -0,0 +1,88 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/NeriusZar/pokedexcli/internal/leveling"
+	"github.com/NeriusZar/pokedexcli/internal/models"
+)
+
+// catchLevel is the level of every freshly caught pokemon.
+const catchLevel = 5
+
+type experienceGain struct {
+	Pokemon    string `json:"pokemon"`
+	Experience int    `json:"experience"`
+	Level      int    `json:"level"`
+	LevelsUp   int    `json:"levels_up"`
+}
+
+func (g experienceGain) String() string {
+	line := fmt.Sprintf("%s gained %d XP", g.Pokemon, g.Experience)
+	if g.LevelsUp > 0 {
+		line += fmt.Sprintf(" and grew to level %d!", g.Level)
+	}
+
+	return line
+}
+
+func newCaughtPokemon(pokemon models.Pokemon, species models.Species, level int) models.CaughtPokemon {
+	return models.CaughtPokemon{
+		Pokemon:    pokemon,
+		Level:      level,
+		Experience: leveling.ExperienceForLevel(species.GrowthRate, level),
+		GrowthRate: species.GrowthRate,
+	}
+}
+
+// gainExperience adds amount experience to the caught pokemon and stores it.
+func (c *config) gainExperience(caught models.CaughtPokemon, amount int) (experienceGain, error) {
+	if caught.GrowthRate == "" {
+		species, err := c.api.GetPokemonSpecies(caught.SpeciesName())
+		if err != nil {
+			return experienceGain{}, err
+		}
+		caught.GrowthRate = species.GrowthRate
+	}
+
+	maxExperience := leveling.ExperienceForLevel(caught.GrowthRate, leveling.MaxLevel)
+	gained := max(0, min(amount, maxExperience-caught.Experience))
+
+	previousLevel := caught.Level
+	caught.Experience += gained
+	caught.Level = max(caught.Level, leveling.LevelForExperience(caught.GrowthRate, caught.Experience))
+
+	if err := c.pokedex.Replace(caught); err != nil {
+		return experienceGain{}, err
+	}
+
+	return experienceGain{
+		Pokemon:    caught.Name,
+		Experience: gained,
+		Level:      caught.Level,
+		LevelsUp:   caught.Level - previousLevel,
+	}, nil
+}
+
+func train(c *config, a ...string) error {
+	if len(a) < 1 {
+		return usageError("You didn't provide pokemon name")
+	}
+
+	caught, err := c.pokedex.Find(a[0])
+	if err != nil {
+		return err
+	}
+	if caught.Level >= leveling.MaxLevel {
+		return &commandError{code: "max_level", message: fmt.Sprintf("%s is already at level %d", caught.Name, leveling.MaxLevel)}
+	}
+
+	gain, err := c.gainExperience(caught, leveling.ExperienceYield(caught.BaseExperience, caught.Level))
+	if err != nil {
+		return err
+	}
+
+	return c.render(gain, func() {
+		fmt.Fprintln(c.out, gain)
+	})
+}
@@ -0,0 +1,142 @@
+package main
+
+import (
+	"cmp"
+	"fmt"
+	"io"
+	"slices"
+	"strconv"
+	"text/tabwriter"
+
+	"github.com/NeriusZar/pokedexcli/internal/encounter"
+	"github.com/NeriusZar/pokedexcli/internal/models"
+)
+
+type travelDocument struct {
+	Location string `json:"location"`
+}
+
+func travel(c *config, a ...string) error {
+	if len(a) < 1 {
+		return usageError("You didn't provide an area to travel to")
+	}
+	area := a[0]
+
+	if _, err := c.api.RetrievePokemonsInArea(area); err != nil {
+		if errorCode(err) == "not_found" {
+			return &commandError{code: "unknown_area", message: fmt.Sprintf("%s is not a location area", area)}
+		}
+		return err
+	}
+
+	c.state.Location = area
+	c.wild = nil
+	if err := c.saveState(); err != nil {
+		return err
+	}
+
+	return c.render(travelDocument{Location: area}, func() {
+		fmt.Fprintf(c.out, "You arrived at %s\n", area)
+	})
+}
+
+func (c *config) saveState() error {
+	if c.statePath == "" {
+		return nil
+	}
+
+	return c.state.Save(c.statePath)
+}
+
+// markSeen records the pokemons as seen, for the progress command.
+func (c *config) markSeen(pokemons ...string) error {
+	if !c.state.MarkSeen(pokemons...) {
+		return nil
+	}
+
+	return c.saveState()
+}
+
+func (c *config) prompt() string {
+	if c.state.Location == "" {
+		return prompt
+	}
+
+	return fmt.Sprintf("Pokedex (%s) > ", c.state.Location)
+}
+
+// catchArea returns the area pokemons can be caught in, and the pokemons
+// living there.
+func (c *config) catchArea() (string, []models.PokemonShortInfo, error) {
+	area, err := c.currentArea()
+	if err != nil {
+		return "", nil, err
+	}
+
+	if c.explored != nil && c.explored.Area == area {
+		return area, c.explored.Pokemons, nil
+	}
+
+	pokemons, err := c.api.RetrievePokemonsInArea(area)
+	return area, pokemons, err
+}
+
+// encounterTable lists the pokemons of an area met in the version with the
+// method, empty for any, sorted by sortBy.
+func encounterTable(details models.AreaEncounters, pokemons []models.PokemonShortInfo, version, method, sortBy string) exploreDocument {
+	doc := exploreDocument{
+		Area:        details.Area,
+		Pokemons:    []models.PokemonShortInfo{},
+		Encounters:  encounter.Summarize(encounter.Filter(details.Encounters, version, method)),
+		MethodRates: []models.EncounterMethodRate{},
+	}
+
+	switch sortBy {
+	case "chance":
+		slices.SortStableFunc(doc.Encounters, func(a, b models.Encounter) int { return cmp.Compare(b.Chance, a.Chance) })
+	case "name":
+		slices.SortStableFunc(doc.Encounters, func(a, b models.Encounter) int { return cmp.Compare(a.Pokemon, b.Pokemon) })
+	}
+
+	for _, e := range doc.Encounters {
+		if slices.ContainsFunc(doc.Pokemons, func(p models.PokemonShortInfo) bool { return p.Name == e.Pokemon }) {
+			continue
+		}
+		if i := slices.IndexFunc(pokemons, func(p models.PokemonShortInfo) bool { return p.Name == e.Pokemon }); i >= 0 {
+			doc.Pokemons = append(doc.Pokemons, pokemons[i])
+		}
+	}
+
+	for _, rate := range details.MethodRates {
+		if (version == "" || rate.Version == version) && encounter.MatchesMethod(rate.Method, method) {
+			doc.MethodRates = append(doc.MethodRates, rate)
+		}
+	}
+
+	return doc
+}
+
+func printEncounterTable(out io.Writer, doc exploreDocument) {
+	w := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "POKEMON\tVERSION\tMETHOD\tLEVELS\tCHANCE")
+	for _, e := range doc.Encounters {
+		levels := strconv.Itoa(e.MinLevel)
+		if e.MaxLevel != e.MinLevel {
+			levels += "-" + strconv.Itoa(e.MaxLevel)
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%d%%\n", e.Pokemon, e.Version, e.Method, levels, e.Chance)
+	}
+	w.Flush()
+
+	if len(doc.MethodRates) == 0 {
+		return
+	}
+
+	fmt.Fprintln(out, "\nEncounter rates:")
+	w = tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "METHOD\tVERSION\tRATE")
+	for _, rate := range doc.MethodRates {
+		fmt.Fprintf(w, "%s\t%s\t%d%%\n", rate.Method, rate.Version, rate.Rate)
+	}
+	w.Flush()
+}
@@ -0,0 +1,222 @@
+package main
+
+import (
+	"cmp"
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+
+	"github.com/NeriusZar/pokedexcli/internal/models"
+	"github.com/NeriusZar/pokedexcli/internal/pokedex"
+)
+
+// lookupSections are the sections of lookup, in the order they are shown.
+var lookupSections = []string{"stats", "abilities", "held-items", "forms", "moves", "game-indices", "cries"}
+
+type lookupDocument struct {
+	Name           string               `json:"name"`
+	ID             int                  `json:"id"`
+	Species        string               `json:"species"`
+	Types          []string             `json:"types"`
+	Height         int                  `json:"height"`
+	Weight         int                  `json:"weight"`
+	BaseExperience int                  `json:"base_experience"`
+	Stats          []models.PokemonStat `json:"stats,omitempty"`
+	BaseStatTotal  int                  `json:"base_stat_total,omitempty"`
+	Abilities      []models.Ability     `json:"abilities,omitempty"`
+	HeldItems      []models.HeldItem    `json:"held_items,omitempty"`
+	Forms          []string             `json:"forms,omitempty"`
+	Moves          []moveGroup          `json:"moves,omitempty"`
+	GameIndices    []models.GameIndex   `json:"game_indices,omitempty"`
+	Cries          *models.Cries        `json:"cries,omitempty"`
+}
+
+// moveGroup lists the moves a pokemon learns with a method in a version
+// group.
+type moveGroup struct {
+	VersionGroup string        `json:"version_group"`
+	Method       string        `json:"method"`
+	Moves        []learnedMove `json:"moves"`
+}
+
+type learnedMove struct {
+	Name  string `json:"name"`
+	Level int    `json:"level,omitempty"`
+	// Move holds the details of the move when they were asked for.
+	Move *models.Move `json:"move,omitempty"`
+}
+
+func lookupFlags() map[string]bool {
+	flags := map[string]bool{"version-group": true, "method": true}
+	for _, section := range lookupSections {
+		flags[section] = false
+	}
+
+	return addSpriteFlags(flags)
+}
+
+// lookup shows any pokemon, by name or national dex ID, without catching it.
+// Section flags pick what is shown, every section by default.
+func lookup(c *config, a ...string) error {
+	args, err := parseArgs(a, lookupFlags())
+	if err != nil {
+		return err
+	}
+	if len(args.positional) < 1 {
+		return usageError("You didn't provide pokemon name or id")
+	}
+
+	// Caught pokemons can be named by ID or nickname.
+	caught, err := c.find(args.positional[0])
+	pokemon := caught.Pokemon
+	if errors.Is(err, pokedex.ErrNotCaught) {
+		pokemon, err = c.api.GetPokemonDetails(args.positional[0])
+	}
+	if err != nil {
+		return err
+	}
+
+	art, err := c.sprite(pokemon, args)
+	if err != nil {
+		return err
+	}
+
+	// Asking for the sprite alone shows no other section, unless it is not
+	// drawn at all, as in structured output.
+	picks := lookupSections
+	if art != "" {
+		picks = slices.Concat(lookupSections, []string{"sprite", "shiny", "back"})
+	}
+	selected := slices.ContainsFunc(picks, args.has)
+	shown := func(section string) bool {
+		return args.has(section) || !selected
+	}
+
+	doc := lookupDocument{
+		Name:           pokemon.Name,
+		ID:             pokemon.ID,
+		Species:        pokemon.SpeciesName(),
+		Types:          pokemon.Types,
+		Height:         pokemon.Height,
+		Weight:         pokemon.Weight,
+		BaseExperience: pokemon.BaseExperience,
+	}
+	if shown("stats") {
+		doc.Stats = pokemon.Stats
+		doc.BaseStatTotal = pokemon.BaseStatTotal()
+	}
+	if shown("abilities") {
+		doc.Abilities = pokemon.Abilities
+	}
+	if shown("held-items") {
+		doc.HeldItems = pokemon.HeldItems
+	}
+	if shown("forms") {
+		doc.Forms = pokemon.Forms
+	}
+	if shown("moves") {
+		doc.Moves = groupMoves(pokemon.Moves, args.value("version-group"), args.value("method"))
+	}
+	if shown("game-indices") {
+		doc.GameIndices = pokemon.GameIndices
+	}
+	if shown("cries") && pokemon.Cries != (models.Cries{}) {
+		doc.Cries = &pokemon.Cries
+	}
+
+	return c.render(doc, func() {
+		fmt.Fprint(c.out, art)
+		fmt.Fprintf(c.out, "Name: %s\n", doc.Name)
+		fmt.Fprintf(c.out, "ID: %d\n", doc.ID)
+		fmt.Fprintf(c.out, "Species: %s\n", doc.Species)
+		fmt.Fprintf(c.out, "Types: %s\n", strings.Join(doc.Types, ", "))
+		fmt.Fprintf(c.out, "Height: %d\n", doc.Height)
+		fmt.Fprintf(c.out, "Weight: %d\n", doc.Weight)
+		fmt.Fprintf(c.out, "Base experience: %d\n", doc.BaseExperience)
+
+		if len(doc.Stats) > 0 {
+			fmt.Fprintln(c.out, "Stats:")
+			for _, stat := range doc.Stats {
+				fmt.Fprintf(c.out, " -%-16s %3d %s\n", stat.Name+":", stat.BaseStat, statBar(stat.BaseStat))
+			}
+			fmt.Fprintf(c.out, "Base stat total: %d\n", doc.BaseStatTotal)
+		}
+		if len(doc.Abilities) > 0 {
+			fmt.Fprintln(c.out, "Abilities:")
+			for _, ability := range doc.Abilities {
+				fmt.Fprintf(c.out, " - %s%s\n", ability.Name, hiddenMark(ability.Hidden))
+			}
+		}
+		if len(doc.HeldItems) > 0 {
+			fmt.Fprintln(c.out, "Held items:")
+			for _, item := range doc.HeldItems {
+				var rarities []string
+				for _, version := range slices.Sorted(maps.Keys(item.Rarities)) {
+					rarities = append(rarities, fmt.Sprintf("%s %d%%", version, item.Rarities[version]))
+				}
+				fmt.Fprintf(c.out, " - %s (%s)\n", item.Name, strings.Join(rarities, ", "))
+			}
+		}
+		if len(doc.Forms) > 0 {
+			fmt.Fprintln(c.out, "Forms:")
+			for _, form := range doc.Forms {
+				fmt.Fprintf(c.out, " - %s\n", form)
+			}
+		}
+		if len(doc.Moves) > 0 {
+			fmt.Fprintln(c.out, "Moves:")
+			printMoveGroups(c.out, doc.Moves)
+		}
+		if len(doc.GameIndices) > 0 {
+			fmt.Fprintln(c.out, "Game indices:")
+			for _, index := range doc.GameIndices {
+				fmt.Fprintf(c.out, " - %s: %d\n", index.Version, index.Index)
+			}
+		}
+		if doc.Cries != nil {
+			fmt.Fprintln(c.out, "Cries:")
+			if doc.Cries.Latest != "" {
+				fmt.Fprintf(c.out, " - latest: %s\n", doc.Cries.Latest)
+			}
+			if doc.Cries.Legacy != "" {
+				fmt.Fprintf(c.out, " - legacy: %s\n", doc.Cries.Legacy)
+			}
+		}
+	})
+}
+
+// groupMoves groups the moves by version group and learn method, keeping the
+// ones of versionGroup and method when they are set. Moves are sorted by
+// level, then name.
+func groupMoves(moves []models.PokemonMove, versionGroup, method string) []moveGroup {
+	var groups []moveGroup
+	for _, move := range moves {
+		for _, learn := range move.Learned {
+			if (versionGroup != "" && learn.VersionGroup != versionGroup) || (method != "" && learn.Method != method) {
+				continue
+			}
+
+			i := slices.IndexFunc(groups, func(g moveGroup) bool {
+				return g.VersionGroup == learn.VersionGroup && g.Method == learn.Method
+			})
+			if i < 0 {
+				groups = append(groups, moveGroup{VersionGroup: learn.VersionGroup, Method: learn.Method})
+				i = len(groups) - 1
+			}
+			groups[i].Moves = append(groups[i].Moves, learnedMove{Name: move.Name, Level: learn.Level})
+		}
+	}
+
+	slices.SortFunc(groups, func(a, b moveGroup) int {
+		return cmp.Or(cmp.Compare(a.VersionGroup, b.VersionGroup), cmp.Compare(a.Method, b.Method))
+	})
+	for _, g := range groups {
+		slices.SortFunc(g.Moves, func(a, b learnedMove) int {
+			return cmp.Or(cmp.Compare(a.Level, b.Level), cmp.Compare(a.Name, b.Name))
+		})
+	}
+
+	return groups
+}
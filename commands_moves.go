@@ -0,0 +1,123 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+
+	"github.com/NeriusZar/pokedexcli/internal/models"
+	"github.com/NeriusZar/pokedexcli/internal/pokedex"
+)
+
+// move shows the details of a move.
+func move(c *config, a ...string) error {
+	if len(a) < 1 {
+		return usageError("You didn't provide move name")
+	}
+
+	m, err := c.api.GetMove(a[0])
+	if err != nil {
+		return err
+	}
+
+	return c.render(m, func() {
+		fmt.Fprintf(c.out, "Name: %s\n", m.Name)
+		fmt.Fprintf(c.out, "Type: %s\n", m.Type)
+		fmt.Fprintf(c.out, "Damage class: %s\n", m.DamageClass)
+		fmt.Fprintf(c.out, "Power: %s\n", orDash(m.Power))
+		fmt.Fprintf(c.out, "Accuracy: %s\n", orDash(m.Accuracy))
+		fmt.Fprintf(c.out, "PP: %d\n", m.PP)
+		fmt.Fprintf(c.out, "Priority: %d\n", m.Priority)
+		if m.Effect != "" {
+			fmt.Fprintf(c.out, "Effect: %s\n", m.Effect)
+		}
+	})
+}
+
+type movesDocument struct {
+	Pokemon string      `json:"pokemon"`
+	Groups  []moveGroup `json:"groups"`
+}
+
+// moves lists the moves a pokemon, caught or not, can learn, by version group
+// and learn method and sorted by level. --details fetches every move.
+func moves(c *config, a ...string) error {
+	args, err := parseArgs(a, map[string]bool{"version-group": true, "method": true, "details": false})
+	if err != nil {
+		return err
+	}
+	if len(args.positional) < 1 {
+		return usageError("You didn't provide pokemon name")
+	}
+
+	// Caught pokemons can be named by ID or nickname.
+	caught, err := c.find(args.positional[0])
+	pokemon := caught.Pokemon
+	if errors.Is(err, pokedex.ErrNotCaught) {
+		pokemon, err = c.api.GetPokemonDetails(args.positional[0])
+	}
+	if err != nil {
+		return err
+	}
+
+	doc := movesDocument{
+		Pokemon: pokemon.Name,
+		Groups:  groupMoves(pokemon.Moves, args.value("version-group"), args.value("method")),
+	}
+	if doc.Groups == nil {
+		doc.Groups = []moveGroup{}
+	}
+
+	if args.has("details") {
+		// Most moves are learned in many version groups, each is only
+		// fetched once.
+		details := map[string]*models.Move{}
+		for _, group := range doc.Groups {
+			for i, learned := range group.Moves {
+				if _, ok := details[learned.Name]; !ok {
+					m, err := c.api.GetMove(learned.Name)
+					if err != nil {
+						return err
+					}
+					details[learned.Name] = &m
+				}
+				group.Moves[i].Move = details[learned.Name]
+			}
+		}
+	}
+
+	return c.render(doc, func() {
+		if len(doc.Groups) == 0 {
+			fmt.Fprintf(c.out, "%s learns no such moves\n", doc.Pokemon)
+			return
+		}
+		fmt.Fprintf(c.out, "Moves %s can learn:\n", doc.Pokemon)
+		printMoveGroups(c.out, doc.Groups)
+	})
+}
+
+func printMoveGroups(w io.Writer, groups []moveGroup) {
+	for _, group := range groups {
+		fmt.Fprintf(w, " %s, %s:\n", group.VersionGroup, group.Method)
+		for _, learned := range group.Moves {
+			line := "  - " + learned.Name
+			if group.Method == "level-up" {
+				line += fmt.Sprintf(" (level %d)", learned.Level)
+			}
+			if m := learned.Move; m != nil {
+				line += fmt.Sprintf(": %s, %s, power %s, accuracy %s, pp %d", m.Type, m.DamageClass, orDash(m.Power), orDash(m.Accuracy), m.PP)
+			}
+			fmt.Fprintln(w, line)
+		}
+	}
+}
+
+// orDash shows the power and accuracy that moves don't have as "-".
+func orDash(n int) string {
+	if n == 0 {
+		return "-"
+	}
+
+	return strconv.Itoa(n)
+}
@@ -0,0 +1,172 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/NeriusZar/pokedexcli/internal/models"
+	"github.com/NeriusZar/pokedexcli/internal/output"
+	"github.com/NeriusZar/pokedexcli/internal/pokedex"
+)
+
+type releaseDocument struct {
+	Released models.CaughtPokemon `json:"released"`
+}
+
+func release(c *config, a ...string) error {
+	args, err := parseArgs(a, map[string]bool{"yes": false})
+	if err != nil {
+		return err
+	}
+	if len(args.positional) < 1 {
+		return usageError("You didn't provide pokemon name")
+	}
+
+	pokemon, err := c.pokedex.Find(args.positional[0])
+	if err != nil {
+		return err
+	}
+	if pokemon.Favorite {
+		return &commandError{code: "favorite", message: fmt.Sprintf("%s is a favorite, unmark it before releasing it", pokemon.DisplayName())}
+	}
+
+	if !args.has("yes") {
+		confirmed, err := c.confirm(fmt.Sprintf("Release %s (#%d, level %d)?", pokemon.DisplayName(), pokemon.InstanceID, pokemon.Level))
+		if err != nil {
+			return err
+		}
+		if !confirmed {
+			c.progress("%s stays with you\n", pokemon.DisplayName())
+			return nil
+		}
+	}
+
+	released, err := c.pokedex.Remove(pokemon.InstanceID)
+	if err != nil {
+		return err
+	}
+
+	return c.render(releaseDocument{Released: released}, func() {
+		fmt.Fprintf(c.out, "%s was released. Bye, %s!\n", released.DisplayName(), released.DisplayName())
+	})
+}
+
+func nickname(c *config, a ...string) error {
+	if len(a) < 2 {
+		return usageError("You need to provide a pokemon and its nickname")
+	}
+
+	pokemon, err := c.pokedex.Find(a[0])
+	if err != nil {
+		return err
+	}
+
+	name := a[1]
+	if _, err := strconv.Atoi(strings.TrimPrefix(name, "#")); err == nil {
+		return usageError("A nickname can't be a number")
+	}
+	if other, err := c.pokedex.Find(name); other.InstanceID != pokemon.InstanceID && !errors.Is(err, pokedex.ErrNotCaught) {
+		return &commandError{code: "nickname_taken", message: fmt.Sprintf("%s already names another pokemon", name)}
+	}
+
+	updated, err := c.pokedex.Update(pokemon.InstanceID, func(p *models.CaughtPokemon) {
+		p.Nickname = name
+	})
+	if err != nil {
+		return err
+	}
+
+	return c.render(updated, func() {
+		fmt.Fprintf(c.out, "%s is now called %s\n", updated.Name, updated.Nickname)
+	})
+}
+
+// favorite toggles whether the pokemon is a favorite. Favorites can't be
+// released.
+func favorite(c *config, a ...string) error {
+	if len(a) < 1 {
+		return usageError("You didn't provide pokemon name")
+	}
+
+	pokemon, err := c.pokedex.Find(a[0])
+	if err != nil {
+		return err
+	}
+
+	updated, err := c.pokedex.Update(pokemon.InstanceID, func(p *models.CaughtPokemon) {
+		p.Favorite = !p.Favorite
+	})
+	if err != nil {
+		return err
+	}
+
+	return c.render(updated, func() {
+		if updated.Favorite {
+			fmt.Fprintf(c.out, "%s is now a favorite\n", updated.DisplayName())
+			return
+		}
+		fmt.Fprintf(c.out, "%s is no longer a favorite\n", updated.DisplayName())
+	})
+}
+
+// confirm asks question and reads the answer from the next input line. It
+// can only ask in interactive sessions with text output.
+func (c *config) confirm(question string) (bool, error) {
+	if c.in == nil || (c.format != output.Text && c.format != "") {
+		return false, usageError("Can't ask for confirmation here, pass --yes")
+	}
+
+	fmt.Fprintf(c.out, "%s [y/N] ", question)
+	if !c.in.Scan() {
+		fmt.Fprintln(c.out)
+		return false, nil
+	}
+
+	answer := strings.ToLower(strings.TrimSpace(c.in.Text()))
+	return answer == "y" || answer == "yes", nil
+}
+
+// refresh fetches the details of the outdated pokemons again and saves them
+// at once, so they are only fetched the first time they are needed. Pokemons
+// whose details can't be fetched are reported and kept as they are.
+func (c *config) refresh(pokemons ...models.CaughtPokemon) ([]models.CaughtPokemon, error) {
+	var refreshed []models.CaughtPokemon
+	for i, p := range pokemons {
+		if !p.Outdated {
+			continue
+		}
+
+		details, err := c.api.GetPokemonDetails(p.Name)
+		if err != nil {
+			c.progress("Couldn't refresh the details of %s: %v\n", p.DisplayName(), err)
+			continue
+		}
+		p.Pokemon = details
+		p.Outdated = false
+		pokemons[i] = p
+		refreshed = append(refreshed, p)
+	}
+
+	if len(refreshed) == 0 {
+		return pokemons, nil
+	}
+
+	return pokemons, c.pokedex.Replace(refreshed...)
+}
+
+// find returns the caught pokemon ref names, refreshed when it is outdated.
+func (c *config) find(ref string) (models.CaughtPokemon, error) {
+	caught, err := c.pokedex.Find(ref)
+	if err != nil {
+		return models.CaughtPokemon{}, err
+	}
+
+	refreshed, err := c.refresh(caught)
+	if err != nil {
+		return models.CaughtPokemon{}, err
+	}
+
+	return refreshed[0], nil
+}
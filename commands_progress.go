@@ -0,0 +1,145 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"text/tabwriter"
+)
+
+// progressCount is how many pokemons of a group were seen and caught.
+type progressCount struct {
+	Name          string  `json:"name"`
+	Region        string  `json:"region,omitempty"`
+	Total         int     `json:"total"`
+	Seen          int     `json:"seen"`
+	Caught        int     `json:"caught"`
+	SeenPercent   float64 `json:"seen_percent"`
+	CaughtPercent float64 `json:"caught_percent"`
+}
+
+type progressDocument struct {
+	National    progressCount   `json:"national"`
+	Generations []progressCount `json:"generations"`
+	Regions     []progressCount `json:"regions"`
+	Types       []progressCount `json:"types"`
+}
+
+func progressCmd(c *config, a ...string) error {
+	c.progress("Comparing your Pokedex with the national dex...\n")
+
+	generations, err := c.api.GetGenerations()
+	if err != nil {
+		return err
+	}
+	typeNames, err := c.api.ListTypes()
+	if err != nil {
+		return err
+	}
+
+	// Caught pokemons count as seen. Species are compared by name, which is
+	// also the name of their default pokemon.
+	caught := map[string]bool{}
+	for _, p := range c.pokedex.GetAll() {
+		caught[p.Name] = true
+		caught[p.SpeciesName()] = true
+	}
+	seen := map[string]bool{}
+	for _, name := range c.state.Seen {
+		seen[name] = true
+	}
+	for name := range caught {
+		seen[name] = true
+	}
+
+	count := func(name string, pokemons []string) progressCount {
+		p := progressCount{Name: name, Total: len(pokemons)}
+		for _, pokemon := range pokemons {
+			if seen[pokemon] {
+				p.Seen++
+			}
+			if caught[pokemon] {
+				p.Caught++
+			}
+		}
+		if p.Total > 0 {
+			p.SeenPercent = 100 * float64(p.Seen) / float64(p.Total)
+			p.CaughtPercent = 100 * float64(p.Caught) / float64(p.Total)
+		}
+		return p
+	}
+
+	doc := progressDocument{Generations: []progressCount{}, Regions: []progressCount{}, Types: []progressCount{}}
+	var national []string
+	var regions []string
+	regionSpecies := map[string][]string{}
+	for _, g := range generations {
+		generation := count(g.Name, g.Species)
+		generation.Region = g.Region
+		doc.Generations = append(doc.Generations, generation)
+
+		national = append(national, g.Species...)
+		if _, ok := regionSpecies[g.Region]; !ok {
+			regions = append(regions, g.Region)
+		}
+		regionSpecies[g.Region] = append(regionSpecies[g.Region], g.Species...)
+	}
+	doc.National = count("national", national)
+	species := map[string]bool{}
+	for _, name := range national {
+		species[name] = true
+	}
+	for _, region := range regions {
+		doc.Regions = append(doc.Regions, count(region, regionSpecies[region]))
+	}
+
+	for _, name := range typeNames {
+		t, err := c.api.GetType(name)
+		if err != nil {
+			return err
+		}
+		// Types also list alternate forms, like charizard-mega-x, which are
+		// not species of their own.
+		var pokemons []string
+		for _, pokemon := range t.Pokemons {
+			if species[pokemon] {
+				pokemons = append(pokemons, pokemon)
+			}
+		}
+		// Some types, like shadow, have no pokemons at all.
+		if len(pokemons) == 0 {
+			continue
+		}
+		doc.Types = append(doc.Types, count(name, pokemons))
+	}
+
+	return c.render(doc, func() {
+		n := doc.National
+		fmt.Fprintf(c.out, "National dex: seen %d/%d (%.1f%%), caught %d/%d (%.1f%%)\n", n.Seen, n.Total, n.SeenPercent, n.Caught, n.Total, n.CaughtPercent)
+
+		fmt.Fprintln(c.out)
+		printProgress(c.out, "GENERATION", doc.Generations, true)
+		fmt.Fprintln(c.out)
+		printProgress(c.out, "REGION", doc.Regions, false)
+		fmt.Fprintln(c.out)
+		printProgress(c.out, "TYPE", doc.Types, false)
+	})
+}
+
+func printProgress(out io.Writer, group string, counts []progressCount, withRegion bool) {
+	w := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+	if withRegion {
+		fmt.Fprintf(w, "%s\tREGION\tSEEN\tCAUGHT\n", group)
+	} else {
+		fmt.Fprintf(w, "%s\tSEEN\tCAUGHT\n", group)
+	}
+
+	for _, p := range counts {
+		if withRegion {
+			fmt.Fprintf(w, "%s\t%s\t", p.Name, p.Region)
+		} else {
+			fmt.Fprintf(w, "%s\t", p.Name)
+		}
+		fmt.Fprintf(w, "%d/%d %.1f%%\t%d/%d %.1f%%\n", p.Seen, p.Total, p.SeenPercent, p.Caught, p.Total, p.CaughtPercent)
+	}
+	w.Flush()
+}
@@ -0,0 +1,47 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/NeriusZar/pokedexcli/internal/models"
+	"github.com/NeriusZar/pokedexcli/internal/output"
+	"github.com/NeriusZar/pokedexcli/internal/sprite"
+)
+
+// addSpriteFlags adds the flags asking for a sprite: --sprite for the front
+// one, and --shiny and --back for the other variants.
+func addSpriteFlags(flags map[string]bool) map[string]bool {
+	flags["sprite"] = false
+	flags["shiny"] = false
+	flags["back"] = false
+
+	return flags
+}
+
+// sprite renders the sprite of pokemon asked for by args, or returns "" when
+// none was asked for. Sprites are only drawn in text output.
+func (c *config) sprite(pokemon models.Pokemon, args commandArgs) (string, error) {
+	if !args.has("sprite") && !args.has("shiny") && !args.has("back") {
+		return "", nil
+	}
+	if c.format != output.Text && c.format != "" {
+		return "", nil
+	}
+
+	url := pokemon.Sprites.URL(args.has("shiny"), args.has("back"))
+	if url == "" {
+		return "", &commandError{code: "no_sprite", message: fmt.Sprintf("%s has no such sprite", pokemon.Name)}
+	}
+
+	img, err := c.api.GetSprite(url)
+	if err != nil {
+		return "", err
+	}
+
+	mode := c.spriteMode
+	if mode == "" {
+		mode = sprite.ASCII
+	}
+
+	return sprite.Render(img, mode), nil
+}
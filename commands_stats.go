@@ -0,0 +1,143 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/NeriusZar/pokedexcli/internal/models"
+	"github.com/NeriusZar/pokedexcli/internal/pokedex"
+	"github.com/NeriusZar/pokedexcli/internal/query"
+)
+
+// maxBaseStat is the highest base stat of any pokemon, which fills a whole
+// stat bar.
+const maxBaseStat = 255
+const statBarWidth = 20
+
+// statBar draws base as a bar scaled to maxBaseStat.
+func statBar(base int) string {
+	filled := min(statBarWidth, (base*statBarWidth+maxBaseStat/2)/maxBaseStat)
+	if base > 0 {
+		filled = max(1, filled)
+	}
+
+	return strings.Repeat("█", filled) + strings.Repeat("░", statBarWidth-filled)
+}
+
+type compareDocument struct {
+	Pokemons []comparedPokemon `json:"pokemons"`
+	// Best names the pokemons with the highest value of every stat and of
+	// the total.
+	Best map[string][]string `json:"best"`
+}
+
+type comparedPokemon struct {
+	Name string `json:"name"`
+	// InstanceID is set for caught pokemons.
+	InstanceID    int            `json:"instance_id,omitempty"`
+	Stats         map[string]int `json:"stats"`
+	BaseStatTotal int            `json:"base_stat_total"`
+}
+
+const totalRow = "total"
+
+// compare lays the base stats of pokemons side by side, marking the best
+// value of every stat. Pokemons are looked up in the Pokedex first, so
+// caught ones can be named by ID or nickname, then in PokeAPI.
+func compare(c *config, a ...string) error {
+	if len(a) < 2 {
+		return usageError("You need to provide at least two pokemons to compare")
+	}
+
+	doc := compareDocument{Best: map[string][]string{}}
+	for _, ref := range a {
+		compared, err := c.comparedPokemon(ref)
+		if err != nil {
+			return err
+		}
+		doc.Pokemons = append(doc.Pokemons, compared)
+	}
+
+	rows := append(slices.Clone(query.Stats), totalRow)
+	value := func(p comparedPokemon, row string) int {
+		if row == totalRow {
+			return p.BaseStatTotal
+		}
+		return p.Stats[row]
+	}
+	best := map[string]int{}
+	for _, row := range rows {
+		for _, p := range doc.Pokemons {
+			best[row] = max(best[row], value(p, row))
+		}
+		for _, p := range doc.Pokemons {
+			if value(p, row) == best[row] {
+				doc.Best[row] = append(doc.Best[row], p.Name)
+			}
+		}
+	}
+
+	return c.render(doc, func() {
+		widths := make([]int, len(doc.Pokemons))
+		header := fmt.Sprintf("%-16s", "")
+		for i, p := range doc.Pokemons {
+			widths[i] = max(6, len(p.Name))
+			header += fmt.Sprintf("  %*s ", widths[i], p.Name)
+		}
+		fmt.Fprintln(c.out, strings.TrimRight(header, " "))
+
+		for _, row := range rows {
+			line := fmt.Sprintf("%-16s", row)
+			for i, p := range doc.Pokemons {
+				line += "  " + c.highlight(fmt.Sprintf("%*d", widths[i], value(p, row)), value(p, row) == best[row])
+			}
+			fmt.Fprintln(c.out, strings.TrimRight(line, " "))
+		}
+	})
+}
+
+// comparedPokemon finds ref in the Pokedex, or any pokemon by that name or
+// national dex ID.
+func (c *config) comparedPokemon(ref string) (comparedPokemon, error) {
+	var pokemon models.Pokemon
+	var compared comparedPokemon
+
+	caught, err := c.pokedex.Find(ref)
+	switch {
+	case err == nil:
+		pokemon = caught.Pokemon
+		compared = comparedPokemon{Name: caught.DisplayName(), InstanceID: caught.InstanceID}
+	case errors.Is(err, pokedex.ErrNotCaught):
+		pokemon, err = c.api.GetPokemonDetails(ref)
+		if err != nil {
+			return comparedPokemon{}, err
+		}
+		compared = comparedPokemon{Name: pokemon.Name}
+	default:
+		return comparedPokemon{}, err
+	}
+
+	compared.Stats = map[string]int{}
+	for _, stat := range pokemon.Stats {
+		compared.Stats[stat.Name] = stat.BaseStat
+	}
+	compared.BaseStatTotal = pokemon.BaseStatTotal()
+
+	return compared, nil
+}
+
+// highlight makes s stand out when on is set, in bold when the terminal has
+// colours and with a star otherwise. Every cell takes one more column than s,
+// for the star.
+func (c *config) highlight(s string, on bool) string {
+	switch {
+	case !on:
+		return s + " "
+	case c.bold:
+		return "\x1b[1m" + s + "\x1b[0m "
+	default:
+		return s + "*"
+	}
+}
@@ -0,0 +1,1261 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/NeriusZar/pokedexcli/internal/api"
+	"github.com/NeriusZar/pokedexcli/internal/models"
+	"github.com/NeriusZar/pokedexcli/internal/output"
+	"github.com/NeriusZar/pokedexcli/internal/pokeapitest"
+	"github.com/NeriusZar/pokedexcli/internal/pokedex"
+	"github.com/NeriusZar/pokedexcli/internal/sprite"
+	"github.com/NeriusZar/pokedexcli/internal/state"
+)
+
+// newTestConfig returns a config talking to server, with a Pokedex and state
+// kept in memory. The bag holds master balls on top of the starting items.
+func newTestConfig(server *pokeapitest.Server) (*config, *bytes.Buffer) {
+	out := &bytes.Buffer{}
+	st := state.New()
+	st.AddItem("master-ball", 10)
+
+	return &config{
+		pagination: models.Pagination{},
+		state:      st,
+		api:        api.NewPokeApi(api.Options{BaseUrl: server.BaseUrl()}),
+		pokedex:    pokedex.NewPokedex(),
+		out:        out,
+		rng:        newRand(1),
+		now:        func() time.Time { return time.Date(2026, 10, 18, 12, 0, 0, 0, time.UTC) },
+	}, out
+}
+
+// addCaught puts a pokemon from the fake server into the Pokedex, bypassing
+// the catch odds.
+func addCaught(t *testing.T, cfg *config, name string, level int) {
+	t.Helper()
+
+	pokemon, err := cfg.api.GetPokemonDetails(name)
+	if err != nil {
+		t.Fatal(err)
+	}
+	species, err := cfg.api.GetPokemonSpecies(pokemon.SpeciesName())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := cfg.pokedex.Add(newCaughtPokemon(pokemon, species, level)); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func areaLines(from, to int) string {
+	names := []string{"canalave-city-area", "viridian-forest-area"}
+	for i := 3; i <= 25; i++ {
+		names = append(names, fmt.Sprintf("route-%d-area", i))
+	}
+
+	return strings.Join(names[from-1:to], "\n") + "\n"
+}
+
+func TestCommands(t *testing.T) {
+	cases := []struct {
+		name     string
+		setup    []string
+		command  string
+		expected string
+		wantErr  bool
+	}{
+		{
+			name:     "map shows the first page",
+			command:  "map",
+			expected: areaLines(1, 20),
+		},
+		{
+			name:     "map shows the next page",
+			setup:    []string{"map"},
+			command:  "map",
+			expected: areaLines(21, 25),
+		},
+		{
+			name:     "mapb on the first page",
+			command:  "mapb",
+			expected: "you're on the first page\n",
+		},
+		{
+			name:     "mapb shows the previous page",
+			setup:    []string{"map", "map"},
+			command:  "mapb",
+			expected: areaLines(1, 20),
+		},
+		{
+			name:     "explore lists pokemons",
+			command:  "explore canalave-city-area",
+			expected: "Exploring canalave-city-area...\nFound Pokemon:\n - tentacool\n - magikarp\n",
+		},
+		{
+			name:     "explore without area",
+			command:  "explore",
+			expected: "",
+			wantErr:  true,
+		},
+		{
+			name:     "explore unknown area",
+			command:  "explore nowhere",
+			expected: "Exploring nowhere...\n",
+			wantErr:  true,
+		},
+		{
+			name:    "explore with encounter details",
+			command: "explore viridian-forest-area --version red --detailed",
+			expected: "Exploring viridian-forest-area...\nFound Pokemon:\n" +
+				"POKEMON   VERSION  METHOD  LEVELS  CHANCE\n" +
+				"caterpie  red      walk    3-5     50%\n" +
+				"pidgey    red      walk    4-6     45%\n" +
+				"pikachu   red      walk    3-5     5%\n" +
+				"\nEncounter rates:\n" +
+				"METHOD  VERSION  RATE\n" +
+				"walk    red      8%\n",
+		},
+		{
+			name:     "explore sorted by chance",
+			command:  "explore viridian-forest-area --version blue --sort chance",
+			expected: "Exploring viridian-forest-area...\nFound Pokemon:\n - pidgey\n - caterpie\n - pikachu\n",
+		},
+		{
+			name:    "explore filtered by method",
+			command: "explore canalave-city-area --method old-rod --detailed",
+			expected: "Exploring canalave-city-area...\nFound Pokemon:\n" +
+				"POKEMON   VERSION  METHOD   LEVELS  CHANCE\n" +
+				"magikarp  diamond  old-rod  3-15    100%\n" +
+				"\nEncounter rates:\n" +
+				"METHOD   VERSION  RATE\n" +
+				"old-rod  diamond  25%\n",
+		},
+		{
+			name:     "explore with an unknown flag",
+			command:  "explore canalave-city-area --verbose",
+			expected: "",
+			wantErr:  true,
+		},
+		{
+			name:     "travel to an area",
+			command:  "travel viridian-forest-area",
+			expected: "You arrived at viridian-forest-area\n",
+		},
+		{
+			name:     "travel to an unknown area",
+			command:  "travel nowhere",
+			expected: "",
+			wantErr:  true,
+		},
+		{
+			name:     "explore defaults to the current location",
+			setup:    []string{"travel viridian-forest-area"},
+			command:  "explore",
+			expected: "Exploring viridian-forest-area...\nFound Pokemon:\n - caterpie\n - pidgey\n - pikachu\n",
+		},
+		{
+			name:     "catch in the current location",
+			setup:    []string{"travel canalave-city-area"},
+			command:  "catch magikarp master-ball",
+			expected: "Throwing a Master Ball at magikarp...\nmagikarp was caught!\n",
+		},
+		{
+			name:     "catch only in the current location",
+			setup:    []string{"explore canalave-city-area", "travel viridian-forest-area"},
+			command:  "catch magikarp master-ball",
+			expected: "",
+			wantErr:  true,
+		},
+		{
+			name:     "walk in the current area",
+			setup:    []string{"travel viridian-forest-area"},
+			command:  "walk",
+			expected: "A wild pikachu (level 3) appeared!\n",
+		},
+		{
+			name:     "fish in the explored area",
+			setup:    []string{"explore canalave-city-area"},
+			command:  "fish",
+			expected: "A wild magikarp (level 4) appeared!\n",
+		},
+		{
+			name:     "encounter by a method the area does not have",
+			setup:    []string{"travel viridian-forest-area"},
+			command:  "surf",
+			expected: "",
+			wantErr:  true,
+		},
+		{
+			name:     "encounter in an unknown version",
+			setup:    []string{"travel viridian-forest-area"},
+			command:  "encounter walk gold",
+			expected: "",
+			wantErr:  true,
+		},
+		{
+			name:     "encounter before travelling",
+			command:  "encounter",
+			expected: "",
+			wantErr:  true,
+		},
+		{
+			name:     "catch adds to the pokedex",
+			setup:    []string{"explore canalave-city-area"},
+			command:  "catch magikarp master-ball",
+			expected: "Throwing a Master Ball at magikarp...\nmagikarp was caught!\n",
+		},
+		{
+			name:     "catch can escape",
+			setup:    []string{"explore canalave-city-area"},
+			command:  "catch tentacool",
+			expected: "Throwing a Pokeball at tentacool...\ntentacool escaped!\n",
+		},
+		{
+			name:     "catch before exploring",
+			command:  "catch magikarp",
+			expected: "",
+			wantErr:  true,
+		},
+		{
+			name:     "catch pokemon from another area",
+			setup:    []string{"explore canalave-city-area"},
+			command:  "catch pikachu",
+			expected: "",
+			wantErr:  true,
+		},
+		{
+			name:     "catch with an unknown ball",
+			setup:    []string{"explore canalave-city-area"},
+			command:  "catch magikarp net-ball",
+			expected: "",
+			wantErr:  true,
+		},
+		{
+			name:     "bag lists money and items",
+			command:  "bag",
+			expected: "Money: 3000\nYour bag:\n - master-ball x10\n - poke-ball x10\n",
+		},
+		{
+			name:     "buy items",
+			command:  "buy great-ball 2",
+			expected: "Bought 2 great-ball for 1200, 1800 left\n",
+		},
+		{
+			name:     "buy more than you can afford",
+			command:  "buy rare-candy",
+			expected: "",
+			wantErr:  true,
+		},
+		{
+			name:     "buy so many the cost overflows",
+			command:  "buy poke-ball 46116860184273880",
+			expected: "",
+			wantErr:  true,
+		},
+		{
+			name:     "buy an item that is not for sale",
+			command:  "buy master-ball",
+			expected: "",
+			wantErr:  true,
+		},
+		{
+			name:     "catch with a ball you don't have",
+			setup:    []string{"explore canalave-city-area"},
+			command:  "catch magikarp ultra-ball",
+			expected: "",
+			wantErr:  true,
+		},
+		{
+			name:     "use an item you don't have",
+			command:  "use rare-candy magikarp",
+			expected: "",
+			wantErr:  true,
+		},
+		{
+			name:     "use a ball",
+			command:  "use poke-ball",
+			expected: "",
+			wantErr:  true,
+		},
+		{
+			name:    "inspect caught pokemon",
+			setup:   []string{"explore canalave-city-area", "catch magikarp master-ball"},
+			command: "inspect magikarp",
+			expected: `Name: magikarp
+ID: #1
+Level: 5
+Experience: 156 (114 to next level)
+Weight: 100
+Height: 9
+Caught: 2026-10-18 in canalave-city-area
+Stats:
+ -hp:               17 (base  20) ██░░░░░░░░░░░░░░░░░░
+ -attack:            6 (base  10) █░░░░░░░░░░░░░░░░░░░
+ -defense:          10 (base  55) ████░░░░░░░░░░░░░░░░
+ -special-attack:    6 (base  15) █░░░░░░░░░░░░░░░░░░░
+ -special-defense:   7 (base  20) ██░░░░░░░░░░░░░░░░░░
+ -speed:            13 (base  80) ██████░░░░░░░░░░░░░░
+Base stat total: 200
+Types:
+ - water
+Abilities:
+ - swift-swim
+ - rattled (hidden)
+`,
+		},
+		{
+			name:     "pokedex groups pokemons by species",
+			setup:    []string{"explore canalave-city-area", "catch magikarp master-ball", "catch tentacool master-ball", "catch magikarp master-ball"},
+			command:  "pokedex",
+			expected: "Your Pokedex:\n - tentacool (#2)\n - magikarp x2 (#1, #3)\n",
+		},
+		{
+			name:     "pokedex sorted by name",
+			setup:    []string{"explore canalave-city-area", "catch magikarp master-ball", "catch tentacool master-ball"},
+			command:  "pokedex --sort name",
+			expected: "Your Pokedex:\n - magikarp (#1)\n - tentacool (#2)\n",
+		},
+		{
+			name:     "pokedex sorted by a stat, descending",
+			setup:    []string{"explore canalave-city-area", "catch tentacool master-ball", "catch magikarp master-ball"},
+			command:  "pokedex --sort -speed",
+			expected: "Your Pokedex:\n - magikarp (#2)\n - tentacool (#1)\n",
+		},
+		{
+			name:     "pokedex filtered by type",
+			setup:    []string{"explore canalave-city-area", "catch magikarp master-ball", "catch tentacool master-ball"},
+			command:  "pokedex --type poison",
+			expected: "Your Pokedex:\n - tentacool (#2)\n",
+		},
+		{
+			name:     "pokedex filtered by a minimum stat and a search",
+			setup:    []string{"explore canalave-city-area", "catch magikarp master-ball", "catch tentacool master-ball"},
+			command:  "pokedex --min-hp 30 --search cool",
+			expected: "Your Pokedex:\n - tentacool (#2)\n",
+		},
+		{
+			name:     "pokedex with a query",
+			setup:    []string{"explore canalave-city-area", "catch magikarp master-ball", "catch tentacool master-ball"},
+			command:  "pokedex --where weight<200,type=water",
+			expected: "Your Pokedex:\n - magikarp (#1)\n",
+		},
+		{
+			name:     "pokedex sorted by an unknown field",
+			command:  "pokedex --sort color",
+			expected: "",
+			wantErr:  true,
+		},
+		{
+			name:     "nickname a pokemon",
+			setup:    []string{"explore canalave-city-area", "catch magikarp master-ball"},
+			command:  "nickname magikarp goldie",
+			expected: "magikarp is now called goldie\n",
+		},
+		{
+			name:     "nicknames are accepted as pokemon names",
+			setup:    []string{"explore canalave-city-area", "catch magikarp master-ball", "catch magikarp master-ball", "nickname #2 goldie", "favorite goldie"},
+			command:  "pokedex",
+			expected: "Your Pokedex:\n - magikarp x2 (#1, #2 goldie ★)\n",
+		},
+		{
+			name:     "nickname taken by another pokemon",
+			setup:    []string{"explore canalave-city-area", "catch magikarp master-ball", "catch tentacool master-ball"},
+			command:  "nickname tentacool magikarp",
+			expected: "",
+			wantErr:  true,
+		},
+		{
+			name:     "release a name shared by a nickname and a species",
+			setup:    []string{"explore canalave-city-area", "catch tentacool master-ball", "nickname tentacool magikarp", "catch magikarp master-ball"},
+			command:  "release magikarp --yes",
+			expected: "",
+			wantErr:  true,
+		},
+		{
+			name:     "favorite a pokemon",
+			setup:    []string{"explore canalave-city-area", "catch magikarp master-ball"},
+			command:  "favorite magikarp",
+			expected: "magikarp is now a favorite\n",
+		},
+		{
+			name:     "release without confirmation",
+			setup:    []string{"explore canalave-city-area", "catch magikarp master-ball"},
+			command:  "release --yes magikarp",
+			expected: "magikarp was released. Bye, magikarp!\n",
+		},
+		{
+			name:     "release a favorite",
+			setup:    []string{"explore canalave-city-area", "catch magikarp master-ball", "favorite magikarp"},
+			command:  "release magikarp --yes",
+			expected: "",
+			wantErr:  true,
+		},
+		{
+			name:     "release a pokemon that was not caught",
+			command:  "release magikarp --yes",
+			expected: "",
+			wantErr:  true,
+		},
+		{
+			name:    "progress counts seen and caught pokemons",
+			setup:   []string{"explore viridian-forest-area", "explore canalave-city-area", "catch magikarp master-ball"},
+			command: "progress",
+			expected: `Comparing your Pokedex with the national dex...
+National dex: seen 5/17 (29.4%), caught 1/17 (5.9%)
+
+GENERATION     REGION  SEEN        CAUGHT
+generation-i   kanto   5/14 35.7%  1/14 7.1%
+generation-ii  johto   0/3 0.0%    0/3 0.0%
+
+REGION  SEEN        CAUGHT
+kanto   5/14 35.7%  1/14 7.1%
+johto   0/3 0.0%    0/3 0.0%
+
+TYPE      SEEN       CAUGHT
+bug       1/2 50.0%  0/2 0.0%
+electric  1/3 33.3%  0/3 0.0%
+flying    1/2 50.0%  0/2 0.0%
+normal    1/2 50.0%  0/2 0.0%
+poison    1/4 25.0%  0/4 0.0%
+water     2/3 66.7%  1/3 33.3%
+`,
+		},
+		{
+			name:     "inspect an ambiguous species",
+			setup:    []string{"explore canalave-city-area", "catch magikarp master-ball", "catch magikarp master-ball"},
+			command:  "inspect magikarp",
+			expected: "",
+			wantErr:  true,
+		},
+		{
+			name:    "matchup with counters",
+			setup:   []string{"explore canalave-city-area", "catch magikarp master-ball"},
+			command: "matchup pidgey",
+			expected: `pidgey (normal/flying)
+Weaknesses:
+ - electric x2
+ - ice x2
+ - rock x2
+Resistances:
+ - bug x0.5
+ - grass x0.5
+Immunities:
+ - ghost x0
+ - ground x0
+`,
+		},
+		{
+			name:    "matchup of a dual type",
+			setup:   []string{"explore canalave-city-area", "catch magikarp master-ball"},
+			command: "matchup tentacool",
+			expected: `tentacool (water/poison)
+Weaknesses:
+ - electric x2
+ - ground x2
+ - psychic x2
+Resistances:
+ - bug x0.5
+ - fairy x0.5
+ - fighting x0.5
+ - fire x0.5
+ - ice x0.5
+ - poison x0.5
+ - steel x0.5
+ - water x0.5
+`,
+		},
+		{
+			name:    "evolutions of a pokemon",
+			command: "evolutions pikachu",
+			expected: `pichu
+└─ pikachu (level-up, min happiness 220)
+   └─ raichu (use-item, thunder-stone)
+`,
+		},
+		{
+			name:     "evolve without the required item",
+			setup:    []string{"explore canalave-city-area", "catch magikarp master-ball"},
+			command:  "evolve magikarp",
+			expected: "magikarp can't evolve yet:\n - gyarados requires level 20\n",
+		},
+		{
+			name:     "evolve a pokemon that was not caught",
+			command:  "evolve pikachu thunder-stone",
+			expected: "",
+			wantErr:  true,
+		},
+		{
+			name:     "train a caught pokemon",
+			setup:    []string{"explore canalave-city-area", "catch magikarp master-ball"},
+			command:  "train magikarp",
+			expected: "magikarp gained 28 XP\n",
+		},
+		{
+			name:     "catching gives experience to the rest of the pokedex",
+			setup:    []string{"explore canalave-city-area", "catch magikarp master-ball"},
+			command:  "catch tentacool master-ball",
+			expected: "Throwing a Master Ball at tentacool...\ntentacool was caught!\nmagikarp gained 47 XP\n",
+		},
+		{
+			name:     "inspect pokemon that was not caught",
+			command:  "inspect pikachu",
+			expected: "",
+			wantErr:  true,
+		},
+		{
+			name:    "lookup abilities and held items",
+			command: "lookup pikachu --abilities --held-items",
+			expected: `Name: pikachu
+ID: 25
+Species: pikachu
+Types: electric
+Height: 4
+Weight: 60
+Base experience: 112
+Abilities:
+ - static
+ - lightning-rod (hidden)
+Held items:
+ - oran-berry (diamond 50%)
+ - light-ball (diamond 5%)
+`,
+		},
+		{
+			name:    "lookup a caught pokemon by nickname",
+			setup:   []string{"explore canalave-city-area", "catch magikarp master-ball", "nickname magikarp goldie"},
+			command: "lookup goldie --abilities",
+			expected: `Name: magikarp
+ID: 129
+Species: magikarp
+Types: water
+Height: 9
+Weight: 100
+Base experience: 40
+Abilities:
+ - swift-swim
+ - rattled (hidden)
+`,
+		},
+		{
+			name:    "lookup level-up moves by id",
+			command: "lookup 25 --moves --version-group red-blue --method level-up",
+			expected: `Name: pikachu
+ID: 25
+Species: pikachu
+Types: electric
+Height: 4
+Weight: 60
+Base experience: 112
+Moves:
+ red-blue, level-up:
+  - growl (level 1)
+  - thunder-shock (level 1)
+  - quick-attack (level 16)
+`,
+		},
+		{
+			name:    "compare a caught pokemon with any pokemon",
+			setup:   []string{"explore canalave-city-area", "catch magikarp master-ball", "nickname magikarp splashy"},
+			command: "compare splashy pikachu",
+			expected: `                  splashy   pikachu
+hp                     20        35*
+attack                 10        55*
+defense                55*       40
+special-attack         15        50*
+special-defense        20        50*
+speed                  80        90*
+total                 200       320*
+`,
+		},
+		{
+			name:     "compare a single pokemon",
+			command:  "compare pikachu",
+			expected: "",
+			wantErr:  true,
+		},
+		{
+			name:    "move details",
+			command: "move thunder-shock",
+			expected: `Name: thunder-shock
+Type: electric
+Damage class: special
+Power: 40
+Accuracy: 100
+PP: 30
+Priority: 0
+Effect: Inflicts regular damage.  Has a 10% chance to paralyze the target.
+`,
+		},
+		{
+			name:     "unknown move",
+			command:  "move missingno",
+			expected: "",
+			wantErr:  true,
+		},
+		{
+			name:    "moves learned by level-up with details",
+			command: "moves pikachu --version-group diamond-pearl --method level-up --details",
+			expected: `Moves pikachu can learn:
+ diamond-pearl, level-up:
+  - growl (level 1): normal, status, power -, accuracy 100, pp 40
+  - thunder-shock (level 1): electric, special, power 40, accuracy 100, pp 30
+  - quick-attack (level 16): normal, physical, power 40, accuracy 100, pp 30
+`,
+		},
+		{
+			name:     "moves of a caught pokemon by nickname",
+			setup:    []string{"explore canalave-city-area", "catch magikarp master-ball", "nickname magikarp splashy"},
+			command:  "moves splashy --method machine",
+			expected: "magikarp learns no such moves\n",
+		},
+		{
+			name:    "ability with the caught pokemons that can have it",
+			setup:   []string{"explore canalave-city-area", "catch magikarp master-ball", "nickname magikarp splashy"},
+			command: "ability swift-swim",
+			expected: `Name: swift-swim
+Effect: Doubles Speed during rain.
+Pokemons:
+ - horsea
+ - goldeen
+ - magikarp
+ - kabuto
+Your pokemons:
+ - magikarp (#1 splashy)
+`,
+		},
+		{
+			name:    "hidden ability no caught pokemon has",
+			command: "ability rain-dish",
+			expected: `Name: rain-dish
+Effect: Heals 1/16 of max HP each turn during rain.
+Pokemons:
+ - tentacool (hidden)
+ - tentacruel (hidden)
+ - lotad
+None of your pokemons can have rain-dish
+`,
+		},
+		{
+			name:     "unknown ability",
+			command:  "ability missingno",
+			expected: "",
+			wantErr:  true,
+		},
+		{
+			name:     "lookup unknown pokemon",
+			command:  "lookup missingno",
+			expected: "",
+			wantErr:  true,
+		},
+	}
+
+	server := pokeapitest.NewServer()
+	defer server.Close()
+
+	commands := getCommands()
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			cfg, out := newTestConfig(server)
+
+			for _, input := range c.setup {
+				words := cleanInput(input)
+				if err := commands[words[0]].callback(cfg, words[1:]...); err != nil {
+					t.Fatalf("setup command %q failed: %v", input, err)
+				}
+			}
+			out.Reset()
+
+			words := cleanInput(c.command)
+			err := commands[words[0]].callback(cfg, words[1:]...)
+			if c.wantErr && err == nil {
+				t.Errorf("expected an error")
+			}
+			if !c.wantErr && err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+
+			if out.String() != c.expected {
+				t.Errorf("unexpected output:\nexpected:\n%s\nactual:\n%s", c.expected, out.String())
+			}
+		})
+	}
+}
+
+func TestCatchUsesCache(t *testing.T) {
+	server := pokeapitest.NewServer()
+	defer server.Close()
+
+	cfg, _ := newTestConfig(server)
+	if err := explore(cfg, "canalave-city-area"); err != nil {
+		t.Fatal(err)
+	}
+	for range 3 {
+		if err := catch(cfg, "magikarp"); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+
+	if requests := server.Requests("/pokemon/magikarp"); requests != 1 {
+		t.Errorf("expected 1 request, got %d", requests)
+	}
+}
+
+func TestStructuredOutput(t *testing.T) {
+	cases := []struct {
+		name     string
+		format   output.Format
+		script   string
+		expected string
+	}{
+		{
+			name:   "explore as json",
+			format: output.JSON,
+			script: "explore canalave-city-area",
+			expected: `{
+  "area": "canalave-city-area",
+  "pokemons": [
+    {
+      "name": "tentacool",
+      "url": "{{base}}/api/v2/pokemon/tentacool/"
+    },
+    {
+      "name": "magikarp",
+      "url": "{{base}}/api/v2/pokemon/magikarp/"
+    }
+  ]
+}
+`,
+		},
+		{
+			name:   "explore as yaml",
+			format: output.YAML,
+			script: "explore canalave-city-area",
+			expected: `---
+area: canalave-city-area
+pokemons:
+  - name: tentacool
+    url: "{{base}}/api/v2/pokemon/tentacool/"
+  - name: magikarp
+    url: "{{base}}/api/v2/pokemon/magikarp/"
+`,
+		},
+		{
+			name:   "errors as json",
+			format: output.JSON,
+			script: "inspect pikachu",
+			expected: `{
+  "error": {
+    "code": "not_caught",
+    "message": "you have not caught that pokemon"
+  }
+}
+`,
+		},
+		{
+			name:   "not found as yaml",
+			format: output.YAML,
+			script: "explore nowhere",
+			expected: `---
+error:
+  code: not_found
+  message: "Failed to fetch pokemons in area: {{base}}/api/v2/location-area/nowhere responded with status code 404"
+`,
+		},
+	}
+
+	server := pokeapitest.NewServer()
+	defer server.Close()
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			cfg, out := newTestConfig(server)
+			cfg.format = c.format
+
+			runCommands(cfg, strings.NewReader(c.script), false)
+
+			expected := strings.ReplaceAll(c.expected, "{{base}}", server.URL)
+			if out.String() != expected {
+				t.Errorf("unexpected output:\nexpected:\n%s\nactual:\n%s", expected, out.String())
+			}
+		})
+	}
+}
+
+func TestBattle(t *testing.T) {
+	server := pokeapitest.NewServer()
+	defer server.Close()
+
+	cfg, out := newTestConfig(server)
+	if err := battleCmd(cfg, "pikachu", "magikarp"); err == nil {
+		t.Errorf("expected an error for a pokemon that was not caught")
+	}
+
+	addCaught(t, cfg, "pikachu", catchLevel)
+	out.Reset()
+	if err := battleCmd(cfg, "pikachu", "magikarp"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !strings.Contains(out.String(), "pikachu won after") {
+		t.Errorf("expected pikachu to beat magikarp, got:\n%s", out.String())
+	}
+
+	replay := func() string {
+		cfg, out := newTestConfig(server)
+		addCaught(t, cfg, "pidgey", catchLevel)
+		if err := battleCmd(cfg, "pidgey", "caterpie"); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		return out.String()
+	}
+	if replay() != replay() {
+		t.Errorf("expected the same seed to replay the same battle")
+	}
+}
+
+func TestMatchupCounters(t *testing.T) {
+	server := pokeapitest.NewServer()
+	defer server.Close()
+
+	cfg, out := newTestConfig(server)
+	for _, name := range []string{"pikachu", "magikarp"} {
+		addCaught(t, cfg, name, catchLevel)
+	}
+
+	if err := matchup(cfg, "pidgey"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	expected := "Best counters in your Pokedex:\n - pikachu: deals x2, takes x1\n"
+	if !strings.HasSuffix(out.String(), expected) {
+		t.Errorf("expected output to end with:\n%s\nactual:\n%s", expected, out.String())
+	}
+}
+
+func TestEvolveWithItem(t *testing.T) {
+	server := pokeapitest.NewServer()
+	defer server.Close()
+
+	cfg, out := newTestConfig(server)
+	addCaught(t, cfg, "pikachu", catchLevel)
+
+	if err := evolve(cfg, "pikachu", "thunder-stone"); err == nil {
+		t.Fatal("expected evolving without a thunder stone in the bag to fail")
+	}
+
+	cfg.state.AddItem("thunder-stone", 1)
+	if err := evolve(cfg, "pikachu", "thunder-stone"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if out.String() != "pikachu evolved into raichu!\n" {
+		t.Errorf("unexpected output: %s", out.String())
+	}
+	if _, ok := cfg.pokedex.Get("pikachu"); ok {
+		t.Errorf("expected pikachu to be replaced")
+	}
+	if raichu, ok := cfg.pokedex.Get("raichu"); !ok || raichu.ID != 26 {
+		t.Errorf("expected raichu in the pokedex, got %+v", raichu)
+	}
+	if cfg.state.Count("thunder-stone") != 0 {
+		t.Errorf("expected the thunder stone to be used up")
+	}
+}
+
+func TestTrainAndEvolveByLevel(t *testing.T) {
+	server := pokeapitest.NewServer()
+	defer server.Close()
+
+	cfg, out := newTestConfig(server)
+	addCaught(t, cfg, "caterpie", 6)
+	caterpie, _ := cfg.pokedex.Get("caterpie")
+	caterpie.Experience = 330
+	cfg.pokedex.Replace(caterpie)
+
+	if err := train(cfg, "caterpie"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if out.String() != "caterpie gained 33 XP and grew to level 7!\n" {
+		t.Errorf("unexpected output: %s", out.String())
+	}
+
+	out.Reset()
+	if err := evolve(cfg, "caterpie"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if out.String() != "caterpie evolved into metapod!\n" {
+		t.Errorf("unexpected output: %s", out.String())
+	}
+
+	metapod, ok := cfg.pokedex.Get("metapod")
+	if !ok || metapod.Level != 7 || metapod.Experience != 330+33 {
+		t.Errorf("expected metapod to keep its level and experience, got %+v", metapod)
+	}
+}
+
+func TestStrictEncounters(t *testing.T) {
+	server := pokeapitest.NewServer()
+	defer server.Close()
+
+	cfg, _ := newTestConfig(server)
+	cfg.strictEncounters = true
+	commands := getCommands()
+
+	if err := commands["travel"].callback(cfg, "canalave-city-area"); err != nil {
+		t.Fatal(err)
+	}
+	if err := commands["catch"].callback(cfg, "magikarp", "master-ball"); err == nil {
+		t.Fatal("expected catching before an encounter to fail")
+	}
+
+	if err := commands["fish"].callback(cfg); err != nil {
+		t.Fatal(err)
+	}
+	if err := commands["catch"].callback(cfg, "tentacool", "master-ball"); err == nil {
+		t.Error("expected catching a pokemon that did not appear to fail")
+	}
+	if err := commands["catch"].callback(cfg, cfg.wild.Pokemon, "master-ball"); err != nil {
+		t.Fatal(err)
+	}
+
+	caught, ok := cfg.pokedex.Get("magikarp")
+	if !ok {
+		t.Fatal("expected magikarp in the pokedex")
+	}
+	if caught.Level != 4 {
+		t.Errorf("expected magikarp to be caught at its encounter level 4, got %d", caught.Level)
+	}
+	if cfg.wild != nil {
+		t.Error("expected the encounter to end with the catch")
+	}
+}
+
+func TestItems(t *testing.T) {
+	server := pokeapitest.NewServer()
+	defer server.Close()
+
+	cfg, out := newTestConfig(server)
+	commands := getCommands()
+	addCaught(t, cfg, "magikarp", catchLevel)
+
+	cfg.state.AddItem("rare-candy", 1)
+	if err := commands["use"].callback(cfg, "rare-candy", "magikarp"); err != nil {
+		t.Fatal(err)
+	}
+	if magikarp, _ := cfg.pokedex.Get("magikarp"); magikarp.Level != catchLevel+1 {
+		t.Errorf("expected a rare candy to raise magikarp to level %d, got %d", catchLevel+1, magikarp.Level)
+	}
+	if cfg.state.Count("rare-candy") != 0 {
+		t.Errorf("expected the rare candy to be used up")
+	}
+
+	if err := commands["explore"].callback(cfg, "canalave-city-area"); err != nil {
+		t.Fatal(err)
+	}
+	out.Reset()
+	if err := commands["catch"].callback(cfg, "tentacool", "master-ball"); err != nil {
+		t.Fatal(err)
+	}
+	if cfg.state.Count("master-ball") != 9 {
+		t.Errorf("expected the master ball to be used up, %d left", cfg.state.Count("master-ball"))
+	}
+}
+
+func TestCatchDropsHeldItems(t *testing.T) {
+	server := pokeapitest.NewServer()
+	defer server.Close()
+
+	cfg, out := newTestConfig(server)
+	commands := getCommands()
+	if err := commands["explore"].callback(cfg, "viridian-forest-area"); err != nil {
+		t.Fatal(err)
+	}
+
+	// Pikachu holds an oran berry half of the time, so a few catches are
+	// bound to drop one.
+	for range 10 {
+		if err := commands["catch"].callback(cfg, "pikachu", "master-ball"); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if cfg.state.Count("oran-berry") == 0 {
+		t.Fatalf("expected an oran berry to drop, got:\n%s", out.String())
+	}
+	if !strings.Contains(out.String(), "pikachu was holding oran-berry!\n") {
+		t.Errorf("expected the drop to be reported, got:\n%s", out.String())
+	}
+}
+
+func TestInspectByInstanceID(t *testing.T) {
+	server := pokeapitest.NewServer()
+	defer server.Close()
+
+	cfg, out := newTestConfig(server)
+	addCaught(t, cfg, "magikarp", 5)
+	addCaught(t, cfg, "magikarp", 12)
+
+	if err := inspect(cfg, "#2"); err != nil {
+		t.Fatal(err)
+	}
+	if !strings.HasPrefix(out.String(), "Name: magikarp\nID: #2\nLevel: 12\n") {
+		t.Errorf("expected the second magikarp, got:\n%s", out.String())
+	}
+}
+
+func TestEncountersMarkPokemonsSeen(t *testing.T) {
+	server := pokeapitest.NewServer()
+	defer server.Close()
+
+	cfg, _ := newTestConfig(server)
+	commands := getCommands()
+
+	if err := commands["travel"].callback(cfg, "canalave-city-area"); err != nil {
+		t.Fatal(err)
+	}
+	if err := commands["fish"].callback(cfg); err != nil {
+		t.Fatal(err)
+	}
+
+	if !cfg.state.HasSeen(cfg.wild.Pokemon) {
+		t.Errorf("expected %s to be seen after meeting it", cfg.wild.Pokemon)
+	}
+	if cfg.state.HasSeen("tentacool") {
+		t.Errorf("expected tentacool not to be seen before exploring")
+	}
+}
+
+func TestSprites(t *testing.T) {
+	server := pokeapitest.NewServer()
+	defer server.Close()
+
+	cases := []struct {
+		name     string
+		mode     sprite.Mode
+		command  string
+		expected string
+	}{
+		{
+			name:     "inspect draws the front sprite",
+			mode:     sprite.ASCII,
+			command:  "inspect magikarp --sprite",
+			expected: "%%\nName: magikarp\n",
+		},
+		{
+			name:     "lookup draws a shiny back sprite",
+			mode:     sprite.ASCII,
+			command:  "lookup pikachu --shiny --back",
+			expected: "++\nName: pikachu\n",
+		},
+		{
+			name:     "sprites in 24-bit colour",
+			mode:     sprite.TrueColor,
+			command:  "lookup pikachu --sprite",
+			expected: strings.Repeat("\x1b[38;2;255;0;0m\x1b[48;2;0;0;255m▀\x1b[0m", 2) + "\nName: pikachu\n",
+		},
+	}
+
+	commands := getCommands()
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			cfg, out := newTestConfig(server)
+			cfg.spriteMode = c.mode
+			addCaught(t, cfg, "magikarp", 5)
+
+			words := cleanInput(c.command)
+			if err := commands[words[0]].callback(cfg, words[1:]...); err != nil {
+				t.Fatal(err)
+			}
+			if !strings.HasPrefix(out.String(), c.expected) {
+				t.Errorf("unexpected output:\nexpected to start with:\n%q\nactual:\n%q", c.expected, out.String())
+			}
+		})
+	}
+}
+
+func TestLookupSpriteInStructuredOutput(t *testing.T) {
+	server := pokeapitest.NewServer()
+	defer server.Close()
+
+	cfg, out := newTestConfig(server)
+	cfg.format = output.JSON
+	if err := lookup(cfg, "pikachu", "--sprite"); err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(out.String(), `"stats"`) || !strings.Contains(out.String(), `"abilities"`) {
+		t.Errorf("expected every section when the sprite is not drawn, got:\n%s", out.String())
+	}
+}
+
+func TestCompareHighlightsInBold(t *testing.T) {
+	server := pokeapitest.NewServer()
+	defer server.Close()
+
+	cfg, out := newTestConfig(server)
+	cfg.spriteMode = sprite.ASCII
+	cfg.bold = true
+	if err := compare(cfg, "pikachu", "magikarp"); err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(out.String(), "\x1b[1m") || strings.Contains(out.String(), "*") {
+		t.Errorf("expected bold highlights whatever the sprite colours, got:\n%q", out.String())
+	}
+}
+
+func TestEvolutionsFollowTheSpeciesUrl(t *testing.T) {
+	server := pokeapitest.NewServer()
+	defer server.Close()
+
+	cfg, _ := newTestConfig(server)
+	if err := evolutions(cfg, "pidgey"); err != nil {
+		t.Fatal(err)
+	}
+	if requests := server.Requests("/pokemon-species/16"); requests != 1 {
+		t.Errorf("expected the species to be fetched from the URL of pidgey, got %d requests", requests)
+	}
+}
+
+func TestMovesUseCache(t *testing.T) {
+	server := pokeapitest.NewServer()
+	defer server.Close()
+
+	cfg, _ := newTestConfig(server)
+	for range 2 {
+		if err := moves(cfg, "pikachu", "--details"); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if requests := server.Requests("/move/growl"); requests != 1 {
+		t.Errorf("expected 1 request, got %d", requests)
+	}
+}
+
+// moveCounter counts the moves fetched through it.
+type moveCounter struct {
+	api.Client
+	fetched map[string]int
+}
+
+func (c moveCounter) GetMove(name string) (models.Move, error) {
+	c.fetched[name]++
+	return c.Client.GetMove(name)
+}
+
+func TestMoveDetailsAreFetchedOnce(t *testing.T) {
+	server := pokeapitest.NewServer()
+	defer server.Close()
+
+	cfg, _ := newTestConfig(server)
+	counter := moveCounter{Client: cfg.api, fetched: map[string]int{}}
+	cfg.api = counter
+	if err := moves(cfg, "pikachu", "--details"); err != nil {
+		t.Fatal(err)
+	}
+
+	for name, fetched := range counter.fetched {
+		if fetched != 1 {
+			t.Errorf("expected %s to be fetched once, got %d", name, fetched)
+		}
+	}
+}
+
+func TestRefreshFillsOutdatedPokemons(t *testing.T) {
+	server := pokeapitest.NewServer()
+	defer server.Close()
+
+	cfg, out := newTestConfig(server)
+	for _, name := range []string{"missingno", "pikachu"} {
+		if _, err := cfg.pokedex.Add(models.CaughtPokemon{Pokemon: models.Pokemon{Name: name}, Nickname: "sparky", Level: 5, Outdated: true}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if err := ability(cfg, "static"); err != nil {
+		t.Fatal(err)
+	}
+	if !strings.HasPrefix(out.String(), "Couldn't refresh the details of sparky: ") {
+		t.Errorf("expected the failed refresh to be reported, got:\n%s", out.String())
+	}
+	if !strings.HasSuffix(out.String(), "Your pokemons:\n - pikachu (#2 sparky)\n") {
+		t.Errorf("expected pikachu to be found, got:\n%s", out.String())
+	}
+
+	pikachu, err := cfg.pokedex.Find("#2")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if pikachu.Outdated || pikachu.Sprites == (models.Sprites{}) || pikachu.Nickname != "sparky" || pikachu.Level != 5 {
+		t.Errorf("expected the details to be filled in and the rest kept, got %+v", pikachu)
+	}
+	if missingno, _ := cfg.pokedex.Find("#1"); !missingno.Outdated {
+		t.Errorf("expected the pokemon that failed to stay outdated")
+	}
+}
+
+// speciesUnavailable fails every species request, as when PokeAPI can't be
+// reached.
+type speciesUnavailable struct {
+	api.Client
+}
+
+func (speciesUnavailable) GetPokemonSpecies(name string) (models.Species, error) {
+	return models.Species{}, fmt.Errorf("%s is unavailable", name)
+}
+
+func TestFailedCatchKeepsTheBall(t *testing.T) {
+	server := pokeapitest.NewServer()
+	defer server.Close()
+
+	cfg, _ := newTestConfig(server)
+	if err := explore(cfg, "canalave-city-area"); err != nil {
+		t.Fatal(err)
+	}
+	cfg.api = speciesUnavailable{cfg.api}
+
+	if err := catch(cfg, "magikarp", "master-ball"); err == nil {
+		t.Fatal("expected the catch to fail")
+	}
+	if count := cfg.state.Count("master-ball"); count != 10 {
+		t.Errorf("expected the ball to be kept, got %d master balls", count)
+	}
+}
+
+func TestWeakenLowersWildHP(t *testing.T) {
+	server := pokeapitest.NewServer()
+	defer server.Close()
+
+	cfg, out := newTestConfig(server)
+	commands := getCommands()
+	addCaught(t, cfg, "pidgey", 3)
+
+	if err := commands["weaken"].callback(cfg, "pidgey"); err == nil {
+		t.Error("expected weakening before an encounter to fail")
+	}
+
+	if err := commands["travel"].callback(cfg, "canalave-city-area"); err != nil {
+		t.Fatal(err)
+	}
+	if err := commands["fish"].callback(cfg); err != nil {
+		t.Fatal(err)
+	}
+	out.Reset()
+	if err := commands["weaken"].callback(cfg, "pidgey"); err != nil {
+		t.Fatal(err)
+	}
+
+	if cfg.wild == nil {
+		t.Fatalf("expected magikarp to withstand a single strike, got:\n%s", out.String())
+	}
+	if cfg.wild.MaxHP == 0 || cfg.wild.HP >= cfg.wild.MaxHP {
+		t.Errorf("expected magikarp to lose HP, got %d/%d:\n%s", cfg.wild.HP, cfg.wild.MaxHP, out.String())
+	}
+	if !strings.HasPrefix(out.String(), "Turn 1: pidgey used ") {
+		t.Errorf("expected the strike to be reported, got:\n%s", out.String())
+	}
+}
@@ -0,0 +1,169 @@
+package main
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/NeriusZar/pokedexcli/internal/models"
+	"github.com/NeriusZar/pokedexcli/internal/typechart"
+)
+
+const maxCounters = 5
+
+type typeMultiplier struct {
+	Type       string  `json:"type"`
+	Multiplier float64 `json:"multiplier"`
+}
+
+type counter struct {
+	Pokemon string `json:"pokemon"`
+	// Deals is the best multiplier of the counter's types against the target.
+	Deals float64 `json:"deals"`
+	// Takes is the worst multiplier of the target's types against the counter.
+	Takes float64 `json:"takes"`
+}
+
+type matchupDocument struct {
+	Pokemon     string           `json:"pokemon"`
+	Types       []string         `json:"types"`
+	Weaknesses  []typeMultiplier `json:"weaknesses"`
+	Resistances []typeMultiplier `json:"resistances"`
+	Immunities  []typeMultiplier `json:"immunities"`
+	Counters    []counter        `json:"counters"`
+}
+
+func matchup(c *config, a ...string) error {
+	if len(a) < 1 {
+		return usageError("You didn't provide pokemon name")
+	}
+
+	target, err := c.findPokemon(a[0])
+	if err != nil {
+		return err
+	}
+
+	chart, err := c.typeChart(target.Types)
+	if err != nil {
+		return err
+	}
+
+	doc := matchupDocument{
+		Pokemon:     target.Name,
+		Types:       target.Types,
+		Weaknesses:  []typeMultiplier{},
+		Resistances: []typeMultiplier{},
+		Immunities:  []typeMultiplier{},
+		Counters:    counters(chart, target, c.pokedex.GetAll()),
+	}
+
+	for t, m := range chart.Defending(target.Types) {
+		entry := typeMultiplier{Type: t, Multiplier: m}
+		switch {
+		case m == 0:
+			doc.Immunities = append(doc.Immunities, entry)
+		case m < 1:
+			doc.Resistances = append(doc.Resistances, entry)
+		default:
+			doc.Weaknesses = append(doc.Weaknesses, entry)
+		}
+	}
+	sortMultipliers(doc.Weaknesses, true)
+	sortMultipliers(doc.Resistances, false)
+	sortMultipliers(doc.Immunities, false)
+
+	return c.render(doc, func() {
+		fmt.Fprintf(c.out, "%s (%s)\n", doc.Pokemon, strings.Join(doc.Types, "/"))
+		printMultipliers(c, "Weaknesses:", doc.Weaknesses)
+		printMultipliers(c, "Resistances:", doc.Resistances)
+		printMultipliers(c, "Immunities:", doc.Immunities)
+
+		if len(doc.Counters) == 0 {
+			return
+		}
+		fmt.Fprintln(c.out, "Best counters in your Pokedex:")
+		for _, counter := range doc.Counters {
+			fmt.Fprintf(c.out, " - %s: deals x%g, takes x%g\n", counter.Pokemon, counter.Deals, counter.Takes)
+		}
+	})
+}
+
+// typeChart builds the chart of every attacking type against the defending
+// types from their damage relations in PokeAPI.
+func (c *config) typeChart(defending []string) (typechart.Chart, error) {
+	types := make([]models.Type, len(defending))
+	for i, name := range defending {
+		t, err := c.api.GetType(name)
+		if err != nil {
+			return nil, err
+		}
+		types[i] = t
+	}
+
+	return typechart.FromTypes(types), nil
+}
+
+// findPokemon looks the pokemon up in the Pokedex first and falls back to the
+// PokeAPI for pokemons that were not caught.
+func (c *config) findPokemon(name string) (models.Pokemon, error) {
+	if caught, ok := c.pokedex.Get(name); ok {
+		return caught.Pokemon, nil
+	}
+
+	return c.api.GetPokemonDetails(name)
+}
+
+// counters ranks the caught pokemons by how well their types fare against the
+// target. chart must be built from the target's types.
+func counters(chart typechart.Chart, target models.Pokemon, caught []models.CaughtPokemon) []counter {
+	var result []counter
+	for _, p := range caught {
+		if p.Name == target.Name {
+			continue
+		}
+
+		deals := 0.0
+		for _, t := range p.Types {
+			deals = max(deals, chart.Multiplier(t, target.Types))
+		}
+		takes := 0.0
+		for _, t := range target.Types {
+			takes = max(takes, chart.Multiplier(t, p.Types))
+		}
+
+		if deals > takes {
+			result = append(result, counter{Pokemon: p.Name, Deals: deals, Takes: takes})
+		}
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		si := result[i].Deals - result[i].Takes
+		sj := result[j].Deals - result[j].Takes
+		if si != sj {
+			return si > sj
+		}
+		return result[i].Pokemon < result[j].Pokemon
+	})
+
+	return result[:min(maxCounters, len(result))]
+}
+
+func sortMultipliers(entries []typeMultiplier, descending bool) {
+	sort.Slice(entries, func(i, j int) bool {
+		if entries[i].Multiplier != entries[j].Multiplier {
+			return (entries[i].Multiplier > entries[j].Multiplier) == descending
+		}
+		return entries[i].Type < entries[j].Type
+	})
+}
+
+func printMultipliers(c *config, title string, entries []typeMultiplier) {
+	if len(entries) == 0 {
+		return
+	}
+
+	fmt.Fprintln(c.out, title)
+	for _, e := range entries {
+		fmt.Fprintf(c.out, " - %s x%g\n", e.Type, e.Multiplier)
+	}
+}
@@ -0,0 +1,38 @@
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/NeriusZar/pokedexcli/internal/models"
+)
+
+const abilityPath = "/ability"
+
+func (api *PokeApi) GetAbility(name string) (models.AbilityDetails, error) {
+	url := api.baseUrl + abilityPath + "/" + name
+
+	data, err := api.fetch(url)
+	if err != nil {
+		return models.AbilityDetails{}, fmt.Errorf("Failed to fetch ability: %w", err)
+	}
+
+	var abilityResponse AbilityResponse
+	if err := json.Unmarshal(data, &abilityResponse); err != nil {
+		return models.AbilityDetails{}, err
+	}
+
+	ability := models.AbilityDetails{
+		ID:     abilityResponse.ID,
+		Name:   abilityResponse.Name,
+		Effect: shortEffect(abilityResponse.EffectEntries),
+	}
+	for _, p := range abilityResponse.Pokemon {
+		ability.Pokemons = append(ability.Pokemons, models.AbilityPokemon{
+			Name:   p.Pokemon.Name,
+			Hidden: p.IsHidden,
+		})
+	}
+
+	return ability, nil
+}
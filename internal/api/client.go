@@ -0,0 +1,35 @@
+package api
+
+import (
+	"image"
+
+	"github.com/NeriusZar/pokedexcli/internal/models"
+	"github.com/NeriusZar/pokedexcli/internal/snapshot"
+)
+
+type Client interface {
+	RetrieveAreas(pageUrl *string) ([]models.Area, models.Pagination, error)
+	RetrievePokemonsInArea(area string) ([]models.PokemonShortInfo, error)
+	GetAreaEncounters(area string) (models.AreaEncounters, error)
+	GetPokemonDetails(name string) (models.Pokemon, error)
+	GetMove(name string) (models.Move, error)
+	GetType(name string) (models.Type, error)
+	GetPokemonSpecies(name string) (models.Species, error)
+	GetPokemonSpeciesByUrl(url string) (models.Species, error)
+	GetEvolutionChain(url string) (models.EvolutionChain, error)
+	GetItem(name string) (models.Item, error)
+	GetAbility(name string) (models.AbilityDetails, error)
+	GetGenerations() ([]models.Generation, error)
+	ListTypes() ([]string, error)
+	GetSprite(url string) (image.Image, error)
+}
+
+// Recorder records the responses of a session into an offline snapshot.
+type Recorder interface {
+	Snapshot(store snapshot.Store, pokemons []string) (int, error)
+}
+
+var (
+	_ Client   = (*PokeApi)(nil)
+	_ Recorder = (*PokeApi)(nil)
+)
@@ -0,0 +1,123 @@
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/NeriusZar/pokedexcli/internal/models"
+)
+
+const speciesPath = "/pokemon-species"
+
+func (api *PokeApi) GetPokemonSpecies(name string) (models.Species, error) {
+	return api.GetPokemonSpeciesByUrl(api.baseUrl + speciesPath + "/" + name)
+}
+
+// GetPokemonSpeciesByUrl follows the species URL of a pokemon.
+func (api *PokeApi) GetPokemonSpeciesByUrl(url string) (models.Species, error) {
+	data, err := api.fetch(url)
+	if err != nil {
+		return models.Species{}, fmt.Errorf("Failed to fetch pokemon species: %w", err)
+	}
+
+	var speciesResponse SpeciesResponse
+	if err := json.Unmarshal(data, &speciesResponse); err != nil {
+		return models.Species{}, err
+	}
+
+	return mapSpeciesResponse(speciesResponse), nil
+}
+
+func mapSpeciesResponse(res SpeciesResponse) models.Species {
+	species := models.Species{
+		ID:                res.ID,
+		Name:              res.Name,
+		EvolutionChainUrl: res.EvolutionChain.URL,
+		GrowthRate:        res.GrowthRate.Name,
+		CaptureRate:       res.CaptureRate,
+	}
+
+	if res.EvolvesFromSpecies != nil {
+		species.EvolvesFrom = res.EvolvesFromSpecies.Name
+	}
+
+	return species
+}
+
+// GetEvolutionChain fetches the chain at url, as found in the species.
+func (api *PokeApi) GetEvolutionChain(url string) (models.EvolutionChain, error) {
+	data, err := api.fetch(url)
+	if err != nil {
+		return models.EvolutionChain{}, fmt.Errorf("Failed to fetch evolution chain: %w", err)
+	}
+
+	var chainResponse EvolutionChainResponse
+	if err := json.Unmarshal(data, &chainResponse); err != nil {
+		return models.EvolutionChain{}, err
+	}
+
+	return models.EvolutionChain{
+		ID:    chainResponse.ID,
+		Chain: mapEvolutionChainLink(chainResponse.Chain),
+	}, nil
+}
+
+func mapEvolutionChainLink(link evolutionChainLink) models.EvolutionNode {
+	node := models.EvolutionNode{
+		Species:   link.Species.Name,
+		EvolvesTo: make([]models.EvolutionNode, len(link.EvolvesTo)),
+	}
+
+	for _, d := range link.EvolutionDetails {
+		detail := models.EvolutionDetail{
+			Trigger: d.Trigger.Name,
+		}
+		if d.MinLevel != nil {
+			detail.MinLevel = *d.MinLevel
+		}
+		if d.Item != nil {
+			detail.Item = d.Item.Name
+		}
+
+		addResource := func(label string, r *namedResource) {
+			if r != nil {
+				detail.Conditions = append(detail.Conditions, label+" "+r.Name)
+			}
+		}
+		addValue := func(label string, v *int) {
+			if v != nil {
+				detail.Conditions = append(detail.Conditions, fmt.Sprintf("%s %d", label, *v))
+			}
+		}
+
+		addResource("holding", d.HeldItem)
+		addResource("knowing", d.KnownMove)
+		addResource("knowing a move of type", d.KnownMoveType)
+		addResource("at", d.Location)
+		addResource("with in party", d.PartySpecies)
+		addResource("with in party a pokemon of type", d.PartyType)
+		addResource("traded for", d.TradeSpecies)
+		addValue("gender", d.Gender)
+		addValue("min happiness", d.MinHappiness)
+		addValue("min beauty", d.MinBeauty)
+		addValue("min affection", d.MinAffection)
+		addValue("attack compared to defense", d.RelativePhysicalStats)
+		if d.TimeOfDay != "" {
+			detail.Conditions = append(detail.Conditions, "during the "+d.TimeOfDay)
+		}
+		if d.NeedsOverworldRain {
+			detail.Conditions = append(detail.Conditions, "while raining")
+		}
+		if d.TurnUpsideDown {
+			detail.Conditions = append(detail.Conditions, "upside down")
+		}
+
+		node.Details = append(node.Details, detail)
+	}
+
+	for i, next := range link.EvolvesTo {
+		node.EvolvesTo[i] = mapEvolutionChainLink(next)
+	}
+
+	return node
+}
@@ -0,0 +1,73 @@
+package api
+
+import (
+	"cmp"
+	"encoding/json"
+	"fmt"
+	"slices"
+
+	"github.com/NeriusZar/pokedexcli/internal/models"
+)
+
+const generationPath = "/generation"
+
+// listLimit is large enough for PokeAPI to return every resource of a kind
+// in a single page.
+const listLimit = 10000
+
+// GetGenerations returns every generation with its species, in order.
+func (api *PokeApi) GetGenerations() ([]models.Generation, error) {
+	names, err := api.listNames(generationPath)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to fetch generations: %w", err)
+	}
+
+	generations := make([]models.Generation, 0, len(names))
+	for _, name := range names {
+		data, err := api.fetch(api.baseUrl + generationPath + "/" + name)
+		if err != nil {
+			return nil, fmt.Errorf("Failed to fetch generation: %w", err)
+		}
+
+		var res GenerationResponse
+		if err := json.Unmarshal(data, &res); err != nil {
+			return nil, err
+		}
+
+		generations = append(generations, models.Generation{
+			ID:      res.ID,
+			Name:    res.Name,
+			Region:  res.MainRegion.Name,
+			Species: resourceNames(res.PokemonSpecies),
+		})
+	}
+
+	slices.SortFunc(generations, func(a, b models.Generation) int { return cmp.Compare(a.ID, b.ID) })
+
+	return generations, nil
+}
+
+// ListTypes returns the names of every type.
+func (api *PokeApi) ListTypes() ([]string, error) {
+	names, err := api.listNames(typePath)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to fetch types: %w", err)
+	}
+
+	return names, nil
+}
+
+// listNames returns the names of every resource listed at path.
+func (api *PokeApi) listNames(path string) ([]string, error) {
+	data, err := api.fetch(fmt.Sprintf("%s%s?limit=%d", api.baseUrl, path, listLimit))
+	if err != nil {
+		return nil, err
+	}
+
+	var res namedResourceList
+	if err := json.Unmarshal(data, &res); err != nil {
+		return nil, err
+	}
+
+	return resourceNames(res.Results), nil
+}
@@ -0,0 +1,43 @@
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/NeriusZar/pokedexcli/internal/models"
+)
+
+const itemPath = "/item"
+
+func (api *PokeApi) GetItem(name string) (models.Item, error) {
+	url := api.baseUrl + itemPath + "/" + name
+
+	data, err := api.fetch(url)
+	if err != nil {
+		return models.Item{}, fmt.Errorf("Failed to fetch item: %w", err)
+	}
+
+	var itemResponse ItemResponse
+	if err := json.Unmarshal(data, &itemResponse); err != nil {
+		return models.Item{}, err
+	}
+
+	return models.Item{
+		ID:       itemResponse.ID,
+		Name:     itemResponse.Name,
+		Cost:     itemResponse.Cost,
+		Category: itemResponse.Category.Name,
+		Effect:   shortEffect(itemResponse.EffectEntries),
+	}, nil
+}
+
+// shortEffect returns the English short effect of the entries.
+func shortEffect(entries []effectEntry) string {
+	for _, e := range entries {
+		if e.Language.Name == "en" {
+			return e.ShortEffect
+		}
+	}
+
+	return ""
+}
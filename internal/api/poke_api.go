@@ -2,71 +2,163 @@ package api
 
 import (
 	"encoding/json"
-	"errors"
 	"fmt"
 	"io"
 	"net/http"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/NeriusZar/pokedexcli/internal/models"
 	"github.com/NeriusZar/pokedexcli/internal/pokecache"
+	"github.com/NeriusZar/pokedexcli/internal/snapshot"
 )
 
 const pokeApiBaseUrl = "https://pokeapi.co/api/v2"
 const locationAreasPath = "/location-area"
 const pokemonDetailsPath = "/pokemon"
+const movePath = "/move"
+const typePath = "/type"
 const cacheInterval = time.Second * 5
+const diskCacheTTL = time.Hour * 24 * 7
+const diskCacheMaxBytes = 50 << 20
+const requestTimeout = time.Second * 30
+
+type Options struct {
+	// BaseUrl defaults to the public PokeAPI.
+	BaseUrl string
+	// CacheDir enables the on-disk response cache when set.
+	CacheDir string
+	// Offline serves every request from the snapshot at SnapshotDir instead
+	// of the network.
+	Offline     bool
+	SnapshotDir string
+}
 
 type PokeApi struct {
-	cache  pokecache.Cache
-	client http.Client
+	baseUrl string
+	cache   pokecache.Cache
+	client  http.Client
+	offline *snapshot.Store
+	visited map[string]struct{}
+}
+
+type StatusError struct {
+	URL        string
+	StatusCode int
+}
+
+func (e *StatusError) Error() string {
+	return fmt.Sprintf("%s responded with status code %d", e.URL, e.StatusCode)
 }
 
-func NewPokeApi() PokeApi {
-	return PokeApi{
-		cache:  pokecache.NewCache(cacheInterval),
-		client: http.Client{},
+func NewPokeApi(opts Options) *PokeApi {
+	cache := pokecache.NewCache(cacheInterval)
+	if opts.CacheDir != "" {
+		cache = pokecache.NewPersistentCache(cacheInterval, opts.CacheDir, diskCacheTTL, diskCacheMaxBytes)
+	}
+
+	baseUrl := opts.BaseUrl
+	if baseUrl == "" {
+		baseUrl = pokeApiBaseUrl
+	}
+
+	api := &PokeApi{
+		baseUrl: baseUrl,
+		cache:   cache,
+		client:  http.Client{Timeout: requestTimeout},
+		visited: map[string]struct{}{},
 	}
+
+	if opts.Offline {
+		store := snapshot.NewStore(opts.SnapshotDir)
+		api.offline = &store
+	}
+
+	return api
 }
 
-func (api *PokeApi) RetrieveAreas(pageUrl *string) ([]models.Area, models.Pagination, error) {
-	url := pokeApiBaseUrl + locationAreasPath
-	if pageUrl != nil {
-		url = *pageUrl
+func (api *PokeApi) Offline() bool {
+	return api.offline != nil
+}
+
+func (api *PokeApi) fetch(url string) ([]byte, error) {
+	data, err := api.load(url, func(data []byte) error {
+		if !json.Valid(data) {
+			return fmt.Errorf("%s returned invalid JSON", url)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
 	}
 
+	api.visited[url] = struct{}{}
+
+	return data, nil
+}
+
+// load returns the response at url from the cache, the offline snapshot or
+// the network. Responses are checked with validate before they are cached.
+func (api *PokeApi) load(url string, validate func([]byte) error) ([]byte, error) {
 	if entry, ok := api.cache.Get(url); ok {
-		var areaResponse AreaResponse
-		if err := json.Unmarshal(entry, &areaResponse); err == nil {
-			areas, pagination := mapAreasResponse(areaResponse)
-			return areas, pagination, nil
-		}
+		return entry, nil
 	}
 
+	var data []byte
+	var err error
+	if api.offline != nil {
+		data, err = api.offline.Get(url)
+	} else {
+		data, err = api.download(url)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if err := validate(data); err != nil {
+		return nil, err
+	}
+
+	api.cache.Add(url, data)
+
+	return data, nil
+}
+
+func (api *PokeApi) download(url string) ([]byte, error) {
 	req, err := http.NewRequest("GET", url, nil)
 	if err != nil {
-		return []models.Area{}, models.Pagination{}, err
+		return nil, err
 	}
 
 	res, err := api.client.Do(req)
 	if err != nil {
-		return []models.Area{}, models.Pagination{}, err
+		return nil, err
 	}
+	defer res.Body.Close()
+
 	if res.StatusCode != http.StatusOK {
-		return []models.Area{}, models.Pagination{}, fmt.Errorf("Failed to fetch areas. Status code %d", res.StatusCode)
+		return nil, &StatusError{URL: url, StatusCode: res.StatusCode}
 	}
-	defer res.Body.Close()
 
-	var areaResponse AreaResponse
-	if err := json.NewDecoder(res.Body).Decode(&areaResponse); err != nil {
-		return []models.Area{}, models.Pagination{}, err
+	return io.ReadAll(res.Body)
+}
+
+func (api *PokeApi) RetrieveAreas(pageUrl *string) ([]models.Area, models.Pagination, error) {
+	url := api.baseUrl + locationAreasPath
+	if pageUrl != nil {
+		url = *pageUrl
 	}
 
-	entry, err := json.Marshal(areaResponse)
+	data, err := api.fetch(url)
 	if err != nil {
+		return []models.Area{}, models.Pagination{}, fmt.Errorf("Failed to fetch areas: %w", err)
+	}
+
+	var areaResponse AreaResponse
+	if err := json.Unmarshal(data, &areaResponse); err != nil {
 		return []models.Area{}, models.Pagination{}, err
 	}
-	api.cache.Add(url, entry)
 
 	areas, pagination := mapAreasResponse(areaResponse)
 	return areas, pagination, nil
@@ -91,42 +183,37 @@ func mapAreasResponse(res AreaResponse) ([]models.Area, models.Pagination) {
 }
 
 func (api *PokeApi) RetrievePokemonsInArea(area string) ([]models.PokemonShortInfo, error) {
-	url := pokeApiBaseUrl + locationAreasPath + "/" + area
-
-	req, err := http.NewRequest("GET", url, nil)
+	areaDetailsResponse, err := api.getAreaDetails(area)
 	if err != nil {
-		return []models.PokemonShortInfo{}, err
+		return []models.PokemonShortInfo{}, fmt.Errorf("Failed to fetch pokemons in area: %w", err)
 	}
 
-	if entry, ok := api.cache.Get(url); ok {
-		var areaDetailsResponse AreaDetailsResponse
-		if err := json.Unmarshal(entry, &areaDetailsResponse); err == nil {
-			return mapPokemonsResponse(areaDetailsResponse), nil
-		}
-	}
+	return mapPokemonsResponse(areaDetailsResponse), nil
+}
 
-	res, err := api.client.Do(req)
+func (api *PokeApi) GetAreaEncounters(area string) (models.AreaEncounters, error) {
+	areaDetailsResponse, err := api.getAreaDetails(area)
 	if err != nil {
-		return []models.PokemonShortInfo{}, err
-	}
-	if res.StatusCode != http.StatusOK {
-		return []models.PokemonShortInfo{}, errors.New("Failed to fetch pokemons in area")
+		return models.AreaEncounters{}, fmt.Errorf("Failed to fetch encounters in area: %w", err)
 	}
-	defer res.Body.Close()
 
-	data, err := io.ReadAll(res.Body)
+	return mapEncountersResponse(areaDetailsResponse), nil
+}
+
+func (api *PokeApi) getAreaDetails(area string) (AreaDetailsResponse, error) {
+	url := api.baseUrl + locationAreasPath + "/" + area
+
+	data, err := api.fetch(url)
 	if err != nil {
-		return []models.PokemonShortInfo{}, errors.New("Failed to fetch pokemons in area")
+		return AreaDetailsResponse{}, err
 	}
 
 	var areaDetailsResponse AreaDetailsResponse
 	if err := json.Unmarshal(data, &areaDetailsResponse); err != nil {
-		return []models.PokemonShortInfo{}, err
+		return AreaDetailsResponse{}, err
 	}
 
-	api.cache.Add(url, data)
-
-	return mapPokemonsResponse(areaDetailsResponse), nil
+	return areaDetailsResponse, nil
 }
 
 func mapPokemonsResponse(res AreaDetailsResponse) []models.PokemonShortInfo {
@@ -142,33 +229,47 @@ func mapPokemonsResponse(res AreaDetailsResponse) []models.PokemonShortInfo {
 	return pokemons
 }
 
-func (api *PokeApi) GetPokemonDetails(name string) (models.Pokemon, error) {
-	url := pokeApiBaseUrl + pokemonDetailsPath + "/" + name
-
-	req, err := http.NewRequest("GET", url, nil)
-	if err != nil {
-		return models.Pokemon{}, err
+func mapEncountersResponse(res AreaDetailsResponse) models.AreaEncounters {
+	encounters := models.AreaEncounters{
+		Area:        res.Name,
+		Encounters:  []models.Encounter{},
+		MethodRates: []models.EncounterMethodRate{},
 	}
 
-	if entry, ok := api.cache.Get(url); ok {
-		var pokemonDetailsResponse PokemonDetailsResponse
-		if err := json.Unmarshal(entry, &pokemonDetailsResponse); err == nil {
-			return mapPokemonDetailsResponse(pokemonDetailsResponse), nil
+	for _, m := range res.EncounterMethodRates {
+		for _, v := range m.VersionDetails {
+			encounters.MethodRates = append(encounters.MethodRates, models.EncounterMethodRate{
+				Method:  m.EncounterMethod.Name,
+				Version: v.Version.Name,
+				Rate:    v.Rate,
+			})
 		}
 	}
 
-	res, err := api.client.Do(req)
-	if err != nil {
-		return models.Pokemon{}, err
-	}
-	if res.StatusCode != http.StatusOK {
-		return models.Pokemon{}, errors.New("Failed to fetch pokemon details")
+	for _, p := range res.PokemonEncounters {
+		for _, v := range p.VersionDetails {
+			for _, d := range v.EncounterDetails {
+				encounters.Encounters = append(encounters.Encounters, models.Encounter{
+					Pokemon:  p.Pokemon.Name,
+					Version:  v.Version.Name,
+					Method:   d.Method.Name,
+					Chance:   d.Chance,
+					MinLevel: d.MinLevel,
+					MaxLevel: d.MaxLevel,
+				})
+			}
+		}
 	}
-	defer res.Body.Close()
 
-	data, err := io.ReadAll(res.Body)
+	return encounters
+}
+
+func (api *PokeApi) GetPokemonDetails(name string) (models.Pokemon, error) {
+	url := api.baseUrl + pokemonDetailsPath + "/" + name
+
+	data, err := api.fetch(url)
 	if err != nil {
-		return models.Pokemon{}, errors.New("Failed to fetch pokemon details")
+		return models.Pokemon{}, fmt.Errorf("Failed to fetch pokemon details: %w", err)
 	}
 
 	var pokemonDetailsResponse PokemonDetailsResponse
@@ -176,8 +277,6 @@ func (api *PokeApi) GetPokemonDetails(name string) (models.Pokemon, error) {
 		return models.Pokemon{}, err
 	}
 
-	api.cache.Add(url, data)
-
 	return mapPokemonDetailsResponse(pokemonDetailsResponse), nil
 }
 
@@ -188,6 +287,8 @@ func mapPokemonDetailsResponse(res PokemonDetailsResponse) models.Pokemon {
 		BaseExperience: res.BaseExperience,
 		Weight:         res.Weight,
 		Height:         res.Height,
+		Species:        res.Species.Name,
+		SpeciesUrl:     res.Species.URL,
 	}
 
 	stats := make([]models.PokemonStat, len(res.Stats))
@@ -195,6 +296,7 @@ func mapPokemonDetailsResponse(res PokemonDetailsResponse) models.Pokemon {
 		stats[i] = models.PokemonStat{
 			Name:     s.Stat.Name,
 			BaseStat: s.BaseStat,
+			Effort:   s.Effort,
 		}
 	}
 
@@ -203,8 +305,146 @@ func mapPokemonDetailsResponse(res PokemonDetailsResponse) models.Pokemon {
 		types[i] = t.Type.Name
 	}
 
+	moves := make([]models.PokemonMove, len(res.Moves))
+	for i, m := range res.Moves {
+		moves[i] = models.PokemonMove{
+			Name: m.Move.Name,
+		}
+		for _, d := range m.VersionGroupDetails {
+			moves[i].Learned = append(moves[i].Learned, models.MoveLearn{
+				Method:       d.MoveLearnMethod.Name,
+				VersionGroup: d.VersionGroup.Name,
+				Level:        d.LevelLearnedAt,
+			})
+		}
+	}
+
 	pokemon.Stats = stats
 	pokemon.Types = types
+	pokemon.Moves = moves
+
+	for _, h := range res.HeldItems {
+		held := models.HeldItem{Name: h.Item.Name, Rarities: map[string]int{}}
+		for _, v := range h.VersionDetails {
+			held.Rarities[v.Version.Name] = v.Rarity
+		}
+		pokemon.HeldItems = append(pokemon.HeldItems, held)
+	}
+
+	for _, a := range res.Abilities {
+		pokemon.Abilities = append(pokemon.Abilities, models.Ability{
+			Name:   a.Ability.Name,
+			Hidden: a.IsHidden,
+			Slot:   a.Slot,
+		})
+	}
+
+	for _, f := range res.Forms {
+		pokemon.Forms = append(pokemon.Forms, f.Name)
+	}
+
+	for _, g := range res.GameIndices {
+		pokemon.GameIndices = append(pokemon.GameIndices, models.GameIndex{
+			Version: g.Version.Name,
+			Index:   g.GameIndex,
+		})
+	}
+
+	pokemon.Cries = models.Cries{Latest: res.Cries.Latest, Legacy: res.Cries.Legacy}
+	pokemon.Sprites = models.Sprites{
+		Front:      res.Sprites.FrontDefault,
+		FrontShiny: res.Sprites.FrontShiny,
+		Back:       res.Sprites.BackDefault,
+		BackShiny:  res.Sprites.BackShiny,
+	}
 
 	return pokemon
 }
+
+func (api *PokeApi) GetMove(name string) (models.Move, error) {
+	url := api.baseUrl + movePath + "/" + name
+
+	data, err := api.fetch(url)
+	if err != nil {
+		return models.Move{}, fmt.Errorf("Failed to fetch move: %w", err)
+	}
+
+	var moveResponse MoveResponse
+	if err := json.Unmarshal(data, &moveResponse); err != nil {
+		return models.Move{}, err
+	}
+
+	return mapMoveResponse(moveResponse), nil
+}
+
+func mapMoveResponse(res MoveResponse) models.Move {
+	move := models.Move{
+		Name:        res.Name,
+		Type:        res.Type.Name,
+		Priority:    res.Priority,
+		DamageClass: res.DamageClass.Name,
+	}
+
+	if res.Power != nil {
+		move.Power = *res.Power
+	}
+	if res.Accuracy != nil {
+		move.Accuracy = *res.Accuracy
+	}
+	if res.PP != nil {
+		move.PP = *res.PP
+	}
+	if res.EffectChance != nil {
+		move.EffectChance = *res.EffectChance
+	}
+	move.Effect = strings.ReplaceAll(shortEffect(res.EffectEntries), "$effect_chance", strconv.Itoa(move.EffectChance))
+
+	return move
+}
+
+func (api *PokeApi) GetType(name string) (models.Type, error) {
+	url := api.baseUrl + typePath + "/" + name
+
+	data, err := api.fetch(url)
+	if err != nil {
+		return models.Type{}, fmt.Errorf("Failed to fetch type: %w", err)
+	}
+
+	var typeResponse TypeResponse
+	if err := json.Unmarshal(data, &typeResponse); err != nil {
+		return models.Type{}, err
+	}
+
+	return mapTypeResponse(typeResponse), nil
+}
+
+func mapTypeResponse(res TypeResponse) models.Type {
+	relations := res.DamageRelations
+
+	pokemons := make([]string, len(res.Pokemon))
+	for i, p := range res.Pokemon {
+		pokemons[i] = p.Pokemon.Name
+	}
+
+	return models.Type{
+		Name: res.Name,
+		DamageRelations: models.DamageRelations{
+			DoubleDamageFrom: resourceNames(relations.DoubleDamageFrom),
+			HalfDamageFrom:   resourceNames(relations.HalfDamageFrom),
+			NoDamageFrom:     resourceNames(relations.NoDamageFrom),
+			DoubleDamageTo:   resourceNames(relations.DoubleDamageTo),
+			HalfDamageTo:     resourceNames(relations.HalfDamageTo),
+			NoDamageTo:       resourceNames(relations.NoDamageTo),
+		},
+		Pokemons: pokemons,
+	}
+}
+
+func resourceNames(resources []namedResource) []string {
+	names := make([]string, len(resources))
+	for i, r := range resources {
+		names[i] = r.Name
+	}
+
+	return names
+}
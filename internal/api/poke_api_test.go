@@ -0,0 +1,120 @@
+package api_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/NeriusZar/pokedexcli/internal/api"
+	"github.com/NeriusZar/pokedexcli/internal/models"
+	"github.com/NeriusZar/pokedexcli/internal/pokeapitest"
+)
+
+func TestGetPokemonDetails(t *testing.T) {
+	server := pokeapitest.NewServer()
+	defer server.Close()
+	base := server.URL
+
+	pokemon, err := api.NewPokeApi(api.Options{BaseUrl: server.BaseUrl()}).GetPokemonDetails("pikachu")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	levelUp := func(level int) []models.MoveLearn {
+		return []models.MoveLearn{
+			{Method: "level-up", VersionGroup: "red-blue", Level: level},
+			{Method: "level-up", VersionGroup: "diamond-pearl", Level: level},
+		}
+	}
+	expected := models.Pokemon{
+		Name:           "pikachu",
+		BaseExperience: 112,
+		ID:             25,
+		Stats: []models.PokemonStat{
+			{Name: "hp", BaseStat: 35},
+			{Name: "attack", BaseStat: 55},
+			{Name: "defense", BaseStat: 40},
+			{Name: "special-attack", BaseStat: 50},
+			{Name: "special-defense", BaseStat: 50},
+			{Name: "speed", BaseStat: 90, Effort: 2},
+		},
+		Types:  []string{"electric"},
+		Weight: 60,
+		Height: 4,
+		Moves: []models.PokemonMove{
+			{Name: "thunder-shock", Learned: levelUp(1)},
+			{Name: "growl", Learned: levelUp(1)},
+			{Name: "quick-attack", Learned: levelUp(16)},
+			{Name: "thunderbolt", Learned: []models.MoveLearn{
+				{Method: "machine", VersionGroup: "red-blue"},
+				{Method: "machine", VersionGroup: "diamond-pearl"},
+			}},
+		},
+		Species:    "pikachu",
+		SpeciesUrl: base + "/api/v2/pokemon-species/25/",
+		HeldItems: []models.HeldItem{
+			{Name: "oran-berry", Rarities: map[string]int{"diamond": 50}},
+			{Name: "light-ball", Rarities: map[string]int{"diamond": 5}},
+		},
+		Abilities: []models.Ability{
+			{Name: "static", Slot: 1},
+			{Name: "lightning-rod", Hidden: true, Slot: 3},
+		},
+		Forms: []string{"pikachu"},
+		GameIndices: []models.GameIndex{
+			{Version: "red", Index: 25},
+			{Version: "blue", Index: 25},
+			{Version: "diamond", Index: 25},
+		},
+		Cries: models.Cries{
+			Latest: base + "/cries/latest/25.ogg",
+			Legacy: base + "/cries/legacy/25.ogg",
+		},
+		Sprites: models.Sprites{
+			Front:      base + "/sprites/pokemon/25.png",
+			FrontShiny: base + "/sprites/pokemon/shiny/25.png",
+			Back:       base + "/sprites/pokemon/back/25.png",
+			BackShiny:  base + "/sprites/pokemon/back/shiny/25.png",
+		},
+	}
+
+	if !reflect.DeepEqual(pokemon, expected) {
+		t.Errorf("unexpected pokemon:\nexpected: %+v\nactual:   %+v", expected, pokemon)
+	}
+}
+
+func TestGetPokemonDetailsByID(t *testing.T) {
+	server := pokeapitest.NewServer()
+	defer server.Close()
+
+	pokemon, err := api.NewPokeApi(api.Options{BaseUrl: server.BaseUrl()}).GetPokemonDetails("25")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if pokemon.Name != "pikachu" {
+		t.Errorf("expected pikachu, got %q", pokemon.Name)
+	}
+}
+
+func TestGetMove(t *testing.T) {
+	server := pokeapitest.NewServer()
+	defer server.Close()
+
+	move, err := api.NewPokeApi(api.Options{BaseUrl: server.BaseUrl()}).GetMove("thunder-shock")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := models.Move{
+		Name:         "thunder-shock",
+		Type:         "electric",
+		Power:        40,
+		Accuracy:     100,
+		PP:           30,
+		DamageClass:  "special",
+		EffectChance: 10,
+		Effect:       "Inflicts regular damage.  Has a 10% chance to paralyze the target.",
+	}
+	if move != expected {
+		t.Errorf("unexpected move:\nexpected: %+v\nactual:   %+v", expected, move)
+	}
+}
@@ -354,3 +354,123 @@ type PokemonDetailsResponse struct {
 	} `json:"types"`
 	Weight int `json:"weight"`
 }
+
+type MoveResponse struct {
+	ID            int           `json:"id"`
+	Name          string        `json:"name"`
+	Accuracy      *int          `json:"accuracy"`
+	Power         *int          `json:"power"`
+	PP            *int          `json:"pp"`
+	Priority      int           `json:"priority"`
+	EffectChance  *int          `json:"effect_chance"`
+	EffectEntries []effectEntry `json:"effect_entries"`
+	DamageClass   struct {
+		Name string `json:"name"`
+		URL  string `json:"url"`
+	} `json:"damage_class"`
+	Type struct {
+		Name string `json:"name"`
+		URL  string `json:"url"`
+	} `json:"type"`
+}
+
+type namedResource struct {
+	Name string `json:"name"`
+	URL  string `json:"url"`
+}
+
+type TypeResponse struct {
+	ID              int    `json:"id"`
+	Name            string `json:"name"`
+	DamageRelations struct {
+		DoubleDamageFrom []namedResource `json:"double_damage_from"`
+		HalfDamageFrom   []namedResource `json:"half_damage_from"`
+		NoDamageFrom     []namedResource `json:"no_damage_from"`
+		DoubleDamageTo   []namedResource `json:"double_damage_to"`
+		HalfDamageTo     []namedResource `json:"half_damage_to"`
+		NoDamageTo       []namedResource `json:"no_damage_to"`
+	} `json:"damage_relations"`
+	Pokemon []struct {
+		Pokemon namedResource `json:"pokemon"`
+		Slot    int           `json:"slot"`
+	} `json:"pokemon"`
+}
+
+type SpeciesResponse struct {
+	ID                 int            `json:"id"`
+	Name               string         `json:"name"`
+	EvolvesFromSpecies *namedResource `json:"evolves_from_species"`
+	GrowthRate         namedResource  `json:"growth_rate"`
+	CaptureRate        int            `json:"capture_rate"`
+	EvolutionChain     struct {
+		URL string `json:"url"`
+	} `json:"evolution_chain"`
+}
+
+type EvolutionChainResponse struct {
+	ID    int                `json:"id"`
+	Chain evolutionChainLink `json:"chain"`
+}
+
+type evolutionChainLink struct {
+	Species          namedResource `json:"species"`
+	EvolutionDetails []struct {
+		Trigger               namedResource  `json:"trigger"`
+		MinLevel              *int           `json:"min_level"`
+		Item                  *namedResource `json:"item"`
+		HeldItem              *namedResource `json:"held_item"`
+		KnownMove             *namedResource `json:"known_move"`
+		KnownMoveType         *namedResource `json:"known_move_type"`
+		Location              *namedResource `json:"location"`
+		PartySpecies          *namedResource `json:"party_species"`
+		PartyType             *namedResource `json:"party_type"`
+		TradeSpecies          *namedResource `json:"trade_species"`
+		Gender                *int           `json:"gender"`
+		MinHappiness          *int           `json:"min_happiness"`
+		MinBeauty             *int           `json:"min_beauty"`
+		MinAffection          *int           `json:"min_affection"`
+		RelativePhysicalStats *int           `json:"relative_physical_stats"`
+		TimeOfDay             string         `json:"time_of_day"`
+		NeedsOverworldRain    bool           `json:"needs_overworld_rain"`
+		TurnUpsideDown        bool           `json:"turn_upside_down"`
+	} `json:"evolution_details"`
+	EvolvesTo []evolutionChainLink `json:"evolves_to"`
+}
+
+type effectEntry struct {
+	Effect      string        `json:"effect"`
+	ShortEffect string        `json:"short_effect"`
+	Language    namedResource `json:"language"`
+}
+
+type ItemResponse struct {
+	ID            int           `json:"id"`
+	Name          string        `json:"name"`
+	Cost          int           `json:"cost"`
+	Category      namedResource `json:"category"`
+	EffectEntries []effectEntry `json:"effect_entries"`
+}
+
+type namedResourceList struct {
+	Count   int             `json:"count"`
+	Next    *string         `json:"next"`
+	Results []namedResource `json:"results"`
+}
+
+type GenerationResponse struct {
+	ID             int             `json:"id"`
+	Name           string          `json:"name"`
+	MainRegion     namedResource   `json:"main_region"`
+	PokemonSpecies []namedResource `json:"pokemon_species"`
+}
+
+type AbilityResponse struct {
+	ID            int           `json:"id"`
+	Name          string        `json:"name"`
+	EffectEntries []effectEntry `json:"effect_entries"`
+	Pokemon       []struct {
+		IsHidden bool          `json:"is_hidden"`
+		Slot     int           `json:"slot"`
+		Pokemon  namedResource `json:"pokemon"`
+	} `json:"pokemon"`
+}
@@ -0,0 +1,66 @@
+package api
+
+import (
+	"encoding/json"
+	"sort"
+	"strings"
+
+	"github.com/NeriusZar/pokedexcli/internal/snapshot"
+)
+
+// Snapshot records every response visited in this session into store, along
+// with the details of every pokemon found in the visited areas and of the
+// extra pokemon names given. It returns the number of recorded responses.
+func (api *PokeApi) Snapshot(store snapshot.Store, pokemons []string) (int, error) {
+	areaPrefix := api.baseUrl + locationAreasPath + "/"
+
+	urls := make([]string, 0, len(api.visited))
+	for url := range api.visited {
+		urls = append(urls, url)
+	}
+	sort.Strings(urls)
+
+	queued := map[string]struct{}{}
+	var queue []string
+	enqueue := func(url string) {
+		if _, ok := queued[url]; ok {
+			return
+		}
+		queued[url] = struct{}{}
+		queue = append(queue, url)
+	}
+
+	for _, url := range urls {
+		enqueue(url)
+	}
+	for _, name := range pokemons {
+		enqueue(api.baseUrl + pokemonDetailsPath + "/" + name)
+	}
+
+	recorded := 0
+	for i := 0; i < len(queue); i++ {
+		url := queue[i]
+
+		data, err := api.fetch(url)
+		if err != nil {
+			return recorded, err
+		}
+
+		if err := store.Put(url, data); err != nil {
+			return recorded, err
+		}
+		recorded++
+
+		if strings.HasPrefix(url, areaPrefix) {
+			var areaDetailsResponse AreaDetailsResponse
+			if err := json.Unmarshal(data, &areaDetailsResponse); err != nil {
+				return recorded, err
+			}
+			for _, p := range areaDetailsResponse.PokemonEncounters {
+				enqueue(api.baseUrl + pokemonDetailsPath + "/" + p.Pokemon.Name)
+			}
+		}
+	}
+
+	return recorded, nil
+}
@@ -0,0 +1,24 @@
+package api
+
+import (
+	"bytes"
+	"fmt"
+	"image"
+	"image/png"
+)
+
+// GetSprite downloads the PNG sprite at url through the cache. Sprites are
+// not recorded in snapshots.
+func (api *PokeApi) GetSprite(url string) (image.Image, error) {
+	data, err := api.load(url, func(data []byte) error {
+		if _, err := png.DecodeConfig(bytes.NewReader(data)); err != nil {
+			return fmt.Errorf("%s returned an invalid PNG: %w", url, err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("Failed to fetch sprite: %w", err)
+	}
+
+	return png.Decode(bytes.NewReader(data))
+}
@@ -0,0 +1,281 @@
+package battle
+
+import (
+	"fmt"
+	"math/rand/v2"
+
+	"github.com/NeriusZar/pokedexcli/internal/leveling"
+	"github.com/NeriusZar/pokedexcli/internal/models"
+	"github.com/NeriusZar/pokedexcli/internal/typechart"
+)
+
+const (
+	DefaultLevel    = 50
+	DefaultMaxTurns = 100
+
+	stab             = 1.5
+	criticalChance   = 24
+	criticalModifier = 1.5
+)
+
+// struggle is used by combatants that know no damaging move.
+var struggle = models.Move{
+	Name:        "struggle",
+	Power:       50,
+	DamageClass: "physical",
+}
+
+type Combatant struct {
+	Pokemon models.Pokemon
+	Level   int
+	Moves   []models.Move
+	// HP the combatant starts with, full health when it is not set.
+	HP int
+}
+
+// MaxHP is the HP of the combatant at full health.
+func (c Combatant) MaxHP() int {
+	if c.Level <= 0 {
+		c.Level = DefaultLevel
+	}
+
+	for _, s := range c.Pokemon.Stats {
+		if s.Name == "hp" {
+			return max(1, leveling.Stat(s.Name, s.BaseStat, c.Level))
+		}
+	}
+
+	return 1
+}
+
+type fighter struct {
+	Combatant
+	stats map[string]int
+	hp    int
+	moves []models.Move
+}
+
+// Event is a single move used during a battle.
+type Event struct {
+	Turn          int     `json:"turn"`
+	Attacker      string  `json:"attacker"`
+	Defender      string  `json:"defender"`
+	Move          string  `json:"move"`
+	Missed        bool    `json:"missed"`
+	Critical      bool    `json:"critical"`
+	Effectiveness float64 `json:"effectiveness"`
+	Damage        int     `json:"damage"`
+	DefenderHP    int     `json:"defender_hp"`
+	Fainted       bool    `json:"fainted"`
+}
+
+type Result struct {
+	Winner string  `json:"winner"`
+	Loser  string  `json:"loser"`
+	Turns  int     `json:"turns"`
+	Log    []Event `json:"log"`
+}
+
+type Battle struct {
+	fighters [2]*fighter
+	chart    typechart.Chart
+	rng      *rand.Rand
+}
+
+// New prepares a battle between a and b. Combatants without a level fight at
+// DefaultLevel. All randomness is drawn from rng, so a seeded rng replays the
+// same battle.
+func New(a, b Combatant, chart typechart.Chart, rng *rand.Rand) *Battle {
+	return &Battle{
+		fighters: [2]*fighter{newFighter(a), newFighter(b)},
+		chart:    chart,
+		rng:      rng,
+	}
+}
+
+func newFighter(c Combatant) *fighter {
+	if c.Level <= 0 {
+		c.Level = DefaultLevel
+	}
+
+	f := &fighter{
+		Combatant: c,
+		stats:     map[string]int{},
+	}
+
+	for _, s := range c.Pokemon.Stats {
+		f.stats[s.Name] = leveling.Stat(s.Name, s.BaseStat, c.Level)
+	}
+	f.stats["hp"] = c.MaxHP()
+	f.hp = f.stats["hp"]
+	if c.HP > 0 {
+		f.hp = min(c.HP, f.hp)
+	}
+
+	for _, m := range c.Moves {
+		if m.Power > 0 {
+			f.moves = append(f.moves, m)
+		}
+	}
+	if len(f.moves) == 0 {
+		f.moves = []models.Move{struggle}
+	}
+
+	return f
+}
+
+// Run plays turns until one side faints or maxTurns is reached, in which case
+// the side with the larger share of its HP left wins.
+func (b *Battle) Run(maxTurns int) Result {
+	var log []Event
+
+	turn := 1
+	for ; turn <= maxTurns; turn++ {
+		for _, a := range b.order() {
+			event := b.attack(turn, a)
+			log = append(log, event)
+			if event.Fainted {
+				return b.result(a.attacker, a.defender, turn, log)
+			}
+		}
+	}
+
+	a, c := b.fighters[0], b.fighters[1]
+	if a.hp*c.stats["hp"] >= c.hp*a.stats["hp"] {
+		return b.result(a, c, maxTurns, log)
+	}
+	return b.result(c, a, maxTurns, log)
+}
+
+// Strike lets the first combatant use one of its moves on the second, without
+// the second striking back.
+func (b *Battle) Strike() Event {
+	a, c := b.fighters[0], b.fighters[1]
+	return b.attack(1, action{attacker: a, defender: c, move: a.moves[b.rng.IntN(len(a.moves))]})
+}
+
+func (b *Battle) result(winner, loser *fighter, turns int, log []Event) Result {
+	return Result{
+		Winner: winner.Pokemon.Name,
+		Loser:  loser.Pokemon.Name,
+		Turns:  turns,
+		Log:    log,
+	}
+}
+
+// action is a move one fighter uses on the other during a turn.
+type action struct {
+	attacker, defender *fighter
+	move               models.Move
+}
+
+// order picks the move of both fighters for a turn and sorts them by
+// priority, then by speed. Ties are broken at random.
+func (b *Battle) order() [2]action {
+	a, c := b.fighters[0], b.fighters[1]
+	first := action{attacker: a, defender: c, move: a.moves[b.rng.IntN(len(a.moves))]}
+	second := action{attacker: c, defender: a, move: c.moves[b.rng.IntN(len(c.moves))]}
+
+	switch {
+	case first.move.Priority != second.move.Priority:
+		if first.move.Priority > second.move.Priority {
+			return [2]action{first, second}
+		}
+	case a.stats["speed"] != c.stats["speed"]:
+		if a.stats["speed"] > c.stats["speed"] {
+			return [2]action{first, second}
+		}
+	case b.rng.IntN(2) == 0:
+		return [2]action{first, second}
+	}
+
+	return [2]action{second, first}
+}
+
+func (b *Battle) attack(turn int, a action) Event {
+	attacker, defender, move := a.attacker, a.defender, a.move
+
+	event := Event{
+		Turn:       turn,
+		Attacker:   attacker.Pokemon.Name,
+		Defender:   defender.Pokemon.Name,
+		Move:       move.Name,
+		DefenderHP: defender.hp,
+	}
+
+	if move.Accuracy > 0 && b.rng.IntN(100) >= move.Accuracy {
+		event.Missed = true
+		return event
+	}
+
+	event.Critical = b.rng.IntN(criticalChance) == 0
+	event.Effectiveness = 1
+	if move.Type != "" {
+		event.Effectiveness = b.chart.Multiplier(move.Type, defender.Pokemon.Types)
+	}
+
+	random := float64(85+b.rng.IntN(16)) / 100
+	event.Damage = damage(attacker, defender, move, event.Effectiveness, event.Critical, random)
+
+	defender.hp = max(0, defender.hp-event.Damage)
+	event.DefenderHP = defender.hp
+	event.Fainted = defender.hp == 0
+
+	return event
+}
+
+// damage implements the damage formula used since generation V. random is the
+// roll between 0.85 and 1.
+func damage(attacker, defender *fighter, move models.Move, effectiveness float64, critical bool, random float64) int {
+	if effectiveness == 0 {
+		return 0
+	}
+
+	attack, defense := attacker.stats["attack"], defender.stats["defense"]
+	if move.DamageClass == "special" {
+		attack, defense = attacker.stats["special-attack"], defender.stats["special-defense"]
+	}
+	attack = max(1, attack)
+	defense = max(1, defense)
+
+	base := (2*attacker.Level/5+2)*move.Power*attack/defense/50 + 2
+
+	modifier := random * effectiveness
+	if critical {
+		modifier *= criticalModifier
+	}
+	for _, t := range attacker.Pokemon.Types {
+		if t == move.Type {
+			modifier *= stab
+			break
+		}
+	}
+
+	return max(1, int(float64(base)*modifier))
+}
+
+func (e Event) String() string {
+	if e.Missed {
+		return fmt.Sprintf("Turn %d: %s used %s, but it missed!", e.Turn, e.Attacker, e.Move)
+	}
+
+	line := fmt.Sprintf("Turn %d: %s used %s", e.Turn, e.Attacker, e.Move)
+	if e.Critical {
+		line += ". A critical hit"
+	}
+	switch {
+	case e.Effectiveness == 0:
+		return line + fmt.Sprintf(". It doesn't affect %s...", e.Defender)
+	case e.Effectiveness > 1:
+		line += ". It's super effective"
+	case e.Effectiveness < 1:
+		line += ". It's not very effective"
+	}
+
+	line += fmt.Sprintf("! %s took %d damage (%d HP left)", e.Defender, e.Damage, e.DefenderHP)
+	if e.Fainted {
+		line += fmt.Sprintf(". %s fainted!", e.Defender)
+	}
+
+	return line
+}
@@ -0,0 +1,111 @@
+package battle
+
+import (
+	"math/rand/v2"
+	"reflect"
+	"testing"
+
+	"github.com/NeriusZar/pokedexcli/internal/models"
+	"github.com/NeriusZar/pokedexcli/internal/typechart"
+)
+
+func stats(hp, attack, defense, spAttack, spDefense, speed int) []models.PokemonStat {
+	return []models.PokemonStat{
+		{Name: "hp", BaseStat: hp},
+		{Name: "attack", BaseStat: attack},
+		{Name: "defense", BaseStat: defense},
+		{Name: "special-attack", BaseStat: spAttack},
+		{Name: "special-defense", BaseStat: spDefense},
+		{Name: "speed", BaseStat: speed},
+	}
+}
+
+var (
+	thunderShock = models.Move{Name: "thunder-shock", Type: "electric", Power: 40, Accuracy: 100, DamageClass: "special"}
+	tackle       = models.Move{Name: "tackle", Type: "normal", Power: 40, Accuracy: 100, DamageClass: "physical"}
+	growl        = models.Move{Name: "growl", Type: "normal", Accuracy: 100, DamageClass: "status"}
+
+	pikachu = Combatant{
+		Pokemon: models.Pokemon{Name: "pikachu", Types: []string{"electric"}, Stats: stats(35, 55, 40, 50, 50, 90)},
+		Moves:   []models.Move{thunderShock, growl},
+	}
+	pidgey = Combatant{
+		Pokemon: models.Pokemon{Name: "pidgey", Types: []string{"normal", "flying"}, Stats: stats(40, 45, 40, 35, 35, 56)},
+		Moves:   []models.Move{tackle},
+	}
+	geodude = Combatant{
+		Pokemon: models.Pokemon{Name: "geodude", Types: []string{"rock", "ground"}, Stats: stats(40, 80, 100, 30, 30, 20)},
+		Moves:   []models.Move{growl},
+	}
+)
+
+func newRand(seed uint64) *rand.Rand {
+	return rand.New(rand.NewPCG(seed, seed))
+}
+
+func TestRunIsDeterministic(t *testing.T) {
+	first := New(pikachu, pidgey, typechart.Default(), newRand(7)).Run(DefaultMaxTurns)
+	second := New(pikachu, pidgey, typechart.Default(), newRand(7)).Run(DefaultMaxTurns)
+
+	if !reflect.DeepEqual(first, second) {
+		t.Errorf("expected the same seed to replay the same battle")
+	}
+	if first.Winner != "pikachu" {
+		t.Errorf("expected pikachu to win, got %s", first.Winner)
+	}
+	if !first.Log[len(first.Log)-1].Fainted {
+		t.Errorf("expected the last event to faint the loser")
+	}
+}
+
+func TestRunAppliesTypeChart(t *testing.T) {
+	result := New(pikachu, geodude, typechart.Default(), newRand(1)).Run(3)
+
+	for _, e := range result.Log {
+		if e.Attacker == "pikachu" && !e.Missed && (e.Damage != 0 || e.Effectiveness != 0) {
+			t.Errorf("expected electric moves to not affect geodude, got %+v", e)
+		}
+	}
+	if result.Turns != 3 {
+		t.Errorf("expected the battle to last all 3 turns, got %d", result.Turns)
+	}
+}
+
+func TestFasterCombatantMovesFirst(t *testing.T) {
+	result := New(pidgey, pikachu, typechart.Default(), newRand(3)).Run(1)
+
+	if result.Log[0].Attacker != "pikachu" {
+		t.Errorf("expected pikachu to move first, got %s", result.Log[0].Attacker)
+	}
+}
+
+func TestPriorityMovesGoFirst(t *testing.T) {
+	quickAttack := models.Move{Name: "quick-attack", Type: "normal", Power: 40, Accuracy: 100, Priority: 1, DamageClass: "physical"}
+	slowpoke := Combatant{
+		Pokemon: models.Pokemon{Name: "slowpoke", Types: []string{"water", "psychic"}, Stats: stats(90, 65, 65, 40, 40, 15)},
+		Moves:   []models.Move{quickAttack},
+	}
+
+	result := New(pikachu, slowpoke, typechart.Default(), newRand(3)).Run(1)
+
+	if result.Log[0].Attacker != "slowpoke" {
+		t.Errorf("expected slowpoke's priority move to go first, got %s", result.Log[0].Attacker)
+	}
+}
+
+func TestStrikeStartsFromGivenHP(t *testing.T) {
+	weakened := pidgey
+	weakened.HP = 5
+
+	event := New(pikachu, weakened, typechart.Default(), newRand(2)).Strike()
+
+	if event.Attacker != "pikachu" {
+		t.Errorf("expected pikachu to strike, got %s", event.Attacker)
+	}
+	if !event.Missed && event.DefenderHP != max(0, 5-event.Damage) {
+		t.Errorf("expected pidgey to start from 5 HP, got %+v", event)
+	}
+	if full := pidgey.MaxHP(); full != 2*40*DefaultLevel/100+DefaultLevel+10 {
+		t.Errorf("expected pidgey to have %d HP at full health, got %d", 2*40*DefaultLevel/100+DefaultLevel+10, full)
+	}
+}
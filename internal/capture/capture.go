@@ -0,0 +1,70 @@
+package capture
+
+import (
+	"math"
+	"math/rand/v2"
+)
+
+// shakeChecks is how many times a ball shakes before the pokemon is caught.
+const shakeChecks = 4
+
+type Ball struct {
+	Name     string  `json:"name"`
+	Label    string  `json:"label"`
+	Modifier float64 `json:"modifier"`
+}
+
+// Balls lists the supported balls by their PokeAPI item name.
+var Balls = map[string]Ball{
+	"poke-ball":   {Name: "poke-ball", Label: "Pokeball", Modifier: 1},
+	"great-ball":  {Name: "great-ball", Label: "Great Ball", Modifier: 1.5},
+	"ultra-ball":  {Name: "ultra-ball", Label: "Ultra Ball", Modifier: 2},
+	"master-ball": {Name: "master-ball", Label: "Master Ball", Modifier: 255},
+}
+
+const DefaultBall = "poke-ball"
+
+// Result of a throw. Shakes is how many shake checks passed before the
+// pokemon broke free, or shakeChecks when it was caught.
+type Result struct {
+	Caught bool `json:"caught"`
+	Shakes int  `json:"shakes"`
+}
+
+// modifiedRate implements the modified catch rate of generations III and IV
+// for a pokemon with the given share of its HP left.
+func modifiedRate(captureRate int, ball Ball, hpFraction float64) float64 {
+	hpFraction = math.Max(0, math.Min(1, hpFraction))
+	return (1 - 2*hpFraction/3) * float64(captureRate) * ball.Modifier
+}
+
+// shakeProbability is the chance to pass a single shake check.
+func shakeProbability(rate float64) float64 {
+	if rate <= 0 {
+		return 0
+	}
+	if rate >= 255 {
+		return 1
+	}
+
+	return 1 / math.Pow(255/rate, 0.25)
+}
+
+// Probability returns the chance of catching a pokemon with the species
+// captureRate using ball, when hpFraction of its HP is left.
+func Probability(captureRate int, ball Ball, hpFraction float64) float64 {
+	return math.Pow(shakeProbability(modifiedRate(captureRate, ball, hpFraction)), shakeChecks)
+}
+
+// Attempt throws ball at a pokemon, drawing the shake checks from rng.
+func Attempt(rng *rand.Rand, captureRate int, ball Ball, hpFraction float64) Result {
+	p := shakeProbability(modifiedRate(captureRate, ball, hpFraction))
+
+	for shakes := 0; shakes < shakeChecks; shakes++ {
+		if rng.Float64() >= p {
+			return Result{Shakes: shakes}
+		}
+	}
+
+	return Result{Caught: true, Shakes: shakeChecks}
+}
@@ -0,0 +1,64 @@
+package capture
+
+import (
+	"math"
+	"math/rand/v2"
+	"testing"
+)
+
+func TestProbability(t *testing.T) {
+	cases := []struct {
+		name        string
+		captureRate int
+		ball        string
+		hpFraction  float64
+		expected    float64
+	}{
+		{"common pokemon at full hp", 255, "poke-ball", 1, 1.0 / 3},
+		{"common pokemon at 1 hp", 255, "poke-ball", 0, 1},
+		{"great ball", 255, "great-ball", 1, 0.5},
+		{"rare pokemon", 3, "ultra-ball", 1, 2.0 / 255},
+		{"master ball", 3, "master-ball", 1, 1},
+		{"uncatchable", 0, "master-ball", 0, 0},
+	}
+
+	for _, c := range cases {
+		actual := Probability(c.captureRate, Balls[c.ball], c.hpFraction)
+		if math.Abs(actual-c.expected) > 1e-9 {
+			t.Errorf("%s: expected %v, got %v", c.name, c.expected, actual)
+		}
+	}
+}
+
+func TestAttemptMatchesProbability(t *testing.T) {
+	const attempts = 20000
+	rng := rand.New(rand.NewPCG(1, 2))
+
+	for _, captureRate := range []int{45, 120, 255} {
+		caught := 0
+		for range attempts {
+			if Attempt(rng, captureRate, Balls[DefaultBall], 1).Caught {
+				caught++
+			}
+		}
+
+		expected := Probability(captureRate, Balls[DefaultBall], 1)
+		actual := float64(caught) / attempts
+		if math.Abs(actual-expected) > 0.015 {
+			t.Errorf("capture rate %d: expected a rate around %.3f, got %.3f", captureRate, expected, actual)
+		}
+	}
+}
+
+func TestAttemptIsDeterministic(t *testing.T) {
+	first := rand.New(rand.NewPCG(9, 9))
+	second := rand.New(rand.NewPCG(9, 9))
+
+	for range 100 {
+		a := Attempt(first, 45, Balls[DefaultBall], 0.5)
+		b := Attempt(second, 45, Balls[DefaultBall], 0.5)
+		if a != b {
+			t.Fatalf("expected the same seed to give the same throws")
+		}
+	}
+}
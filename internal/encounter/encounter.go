@@ -0,0 +1,119 @@
+package encounter
+
+import (
+	"math/rand/v2"
+	"slices"
+	"strings"
+
+	"github.com/NeriusZar/pokedexcli/internal/models"
+)
+
+// Encounter methods, as PokeAPI names them.
+const (
+	Walk = "walk"
+	Surf = "surf"
+	// Fish is the method family covering every fishing rod.
+	Fish = "fish"
+)
+
+// Wild is a pokemon met in the wild.
+type Wild struct {
+	Pokemon string `json:"pokemon"`
+	Level   int    `json:"level"`
+	Area    string `json:"area"`
+	Method  string `json:"method"`
+	Version string `json:"version"`
+	// HP left once it has been weakened, it is at full health before.
+	HP    int `json:"hp,omitempty"`
+	MaxHP int `json:"max_hp,omitempty"`
+}
+
+// MatchesMethod reports whether method is part of family. An empty family
+// matches every method and Fish matches every rod.
+func MatchesMethod(method, family string) bool {
+	switch family {
+	case "":
+		return true
+	case Fish:
+		return strings.HasSuffix(method, "-rod")
+	default:
+		return method == family
+	}
+}
+
+// Versions returns the game versions the encounters belong to, in order of
+// appearance.
+func Versions(encounters []models.Encounter) []string {
+	versions := []string{}
+	for _, e := range encounters {
+		if !slices.Contains(versions, e.Version) {
+			versions = append(versions, e.Version)
+		}
+	}
+
+	return versions
+}
+
+// Filter returns the encounters of the version whose method is part of
+// family. An empty version matches every version.
+func Filter(encounters []models.Encounter, version, family string) []models.Encounter {
+	filtered := []models.Encounter{}
+	for _, e := range encounters {
+		if (version == "" || e.Version == version) && MatchesMethod(e.Method, family) {
+			filtered = append(filtered, e)
+		}
+	}
+
+	return filtered
+}
+
+// Summarize merges the encounters of a pokemon with the same version and
+// method, which PokeAPI lists once per slot, adding up their chances and
+// widening the level range.
+func Summarize(encounters []models.Encounter) []models.Encounter {
+	summary := []models.Encounter{}
+	for _, e := range encounters {
+		i := slices.IndexFunc(summary, func(s models.Encounter) bool {
+			return s.Pokemon == e.Pokemon && s.Version == e.Version && s.Method == e.Method
+		})
+		if i < 0 {
+			summary = append(summary, e)
+			continue
+		}
+
+		summary[i].Chance += e.Chance
+		summary[i].MinLevel = min(summary[i].MinLevel, e.MinLevel)
+		summary[i].MaxLevel = max(summary[i].MaxLevel, e.MaxLevel)
+	}
+
+	return summary
+}
+
+// Roll picks one of the encounters weighted by its chance, and a level in
+// its range. It returns false when no encounter has a chance to happen.
+func Roll(rng *rand.Rand, encounters []models.Encounter) (Wild, bool) {
+	total := 0
+	for _, e := range encounters {
+		total += max(0, e.Chance)
+	}
+	if total == 0 {
+		return Wild{}, false
+	}
+
+	n := rng.IntN(total)
+	for _, e := range encounters {
+		if n >= max(0, e.Chance) {
+			n -= max(0, e.Chance)
+			continue
+		}
+
+		return Wild{
+			Pokemon: e.Pokemon,
+			Level:   e.MinLevel + rng.IntN(max(0, e.MaxLevel-e.MinLevel)+1),
+			Method:  e.Method,
+			Version: e.Version,
+		}, true
+	}
+
+	return Wild{}, false
+}
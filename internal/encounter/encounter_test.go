@@ -0,0 +1,109 @@
+package encounter
+
+import (
+	"math"
+	"math/rand/v2"
+	"reflect"
+	"testing"
+
+	"github.com/NeriusZar/pokedexcli/internal/models"
+)
+
+var encounters = []models.Encounter{
+	{Pokemon: "caterpie", Version: "red", Method: "walk", Chance: 50, MinLevel: 3, MaxLevel: 5},
+	{Pokemon: "pidgey", Version: "red", Method: "walk", Chance: 40, MinLevel: 4, MaxLevel: 4},
+	{Pokemon: "pikachu", Version: "red", Method: "walk", Chance: 10, MinLevel: 3, MaxLevel: 5},
+	{Pokemon: "magikarp", Version: "red", Method: "old-rod", Chance: 100, MinLevel: 5, MaxLevel: 5},
+	{Pokemon: "pidgey", Version: "blue", Method: "walk", Chance: 100, MinLevel: 4, MaxLevel: 6},
+}
+
+func TestFilter(t *testing.T) {
+	cases := []struct {
+		name     string
+		version  string
+		family   string
+		expected []string
+	}{
+		{"every method", "red", "", []string{"caterpie", "pidgey", "pikachu", "magikarp"}},
+		{"walking", "red", "walk", []string{"caterpie", "pidgey", "pikachu"}},
+		{"fishing", "red", Fish, []string{"magikarp"}},
+		{"other version", "blue", "walk", []string{"pidgey"}},
+		{"no encounters", "red", "surf", []string{}},
+		{"every version", "", "walk", []string{"caterpie", "pidgey", "pikachu", "pidgey"}},
+	}
+
+	for _, c := range cases {
+		actual := []string{}
+		for _, e := range Filter(encounters, c.version, c.family) {
+			actual = append(actual, e.Pokemon)
+		}
+		if !reflect.DeepEqual(actual, c.expected) {
+			t.Errorf("%s: expected %v, got %v", c.name, c.expected, actual)
+		}
+	}
+}
+
+func TestSummarize(t *testing.T) {
+	slots := []models.Encounter{
+		{Pokemon: "magikarp", Version: "red", Method: "old-rod", Chance: 60, MinLevel: 5, MaxLevel: 5},
+		{Pokemon: "tentacool", Version: "red", Method: "surf", Chance: 100, MinLevel: 20, MaxLevel: 30},
+		{Pokemon: "magikarp", Version: "red", Method: "old-rod", Chance: 40, MinLevel: 10, MaxLevel: 15},
+		{Pokemon: "magikarp", Version: "blue", Method: "old-rod", Chance: 100, MinLevel: 5, MaxLevel: 5},
+	}
+
+	expected := []models.Encounter{
+		{Pokemon: "magikarp", Version: "red", Method: "old-rod", Chance: 100, MinLevel: 5, MaxLevel: 15},
+		{Pokemon: "tentacool", Version: "red", Method: "surf", Chance: 100, MinLevel: 20, MaxLevel: 30},
+		{Pokemon: "magikarp", Version: "blue", Method: "old-rod", Chance: 100, MinLevel: 5, MaxLevel: 5},
+	}
+	if actual := Summarize(slots); !reflect.DeepEqual(actual, expected) {
+		t.Errorf("expected %v, got %v", expected, actual)
+	}
+}
+
+func TestVersions(t *testing.T) {
+	expected := []string{"red", "blue"}
+	if actual := Versions(encounters); !reflect.DeepEqual(actual, expected) {
+		t.Errorf("expected %v, got %v", expected, actual)
+	}
+}
+
+func TestRollFollowsChances(t *testing.T) {
+	const rolls = 20000
+	rng := rand.New(rand.NewPCG(1, 2))
+	walking := Filter(encounters, "red", "walk")
+
+	counts := map[string]int{}
+	for range rolls {
+		wild, ok := Roll(rng, walking)
+		if !ok {
+			t.Fatal("expected an encounter")
+		}
+		counts[wild.Pokemon]++
+
+		for _, e := range walking {
+			if e.Pokemon == wild.Pokemon && (wild.Level < e.MinLevel || wild.Level > e.MaxLevel) {
+				t.Fatalf("%s at level %d is out of its range", wild.Pokemon, wild.Level)
+			}
+		}
+	}
+
+	for _, e := range walking {
+		actual := float64(counts[e.Pokemon]) / rolls
+		expected := float64(e.Chance) / 100
+		if math.Abs(actual-expected) > 0.015 {
+			t.Errorf("%s: expected a rate around %.2f, got %.3f", e.Pokemon, expected, actual)
+		}
+	}
+}
+
+func TestRollWithoutEncounters(t *testing.T) {
+	rng := rand.New(rand.NewPCG(1, 2))
+
+	if _, ok := Roll(rng, nil); ok {
+		t.Error("expected no encounter")
+	}
+	if _, ok := Roll(rng, []models.Encounter{{Pokemon: "mew", Chance: 0}}); ok {
+		t.Error("expected no encounter for a zero chance")
+	}
+}
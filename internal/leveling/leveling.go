@@ -0,0 +1,77 @@
+package leveling
+
+const (
+	MinLevel = 1
+	MaxLevel = 100
+)
+
+// ExperienceForLevel returns the total experience a pokemon of the given
+// PokeAPI growth rate needs to reach level. Unknown growth rates are treated
+// as "medium", the most common one.
+func ExperienceForLevel(growthRate string, level int) int {
+	level = max(MinLevel, min(MaxLevel, level))
+	if level == MinLevel {
+		return 0
+	}
+
+	n := level
+	cube := n * n * n
+
+	switch growthRate {
+	case "fast":
+		return 4 * cube / 5
+	case "medium-slow":
+		return 6*cube/5 - 15*n*n + 100*n - 140
+	case "slow":
+		return 5 * cube / 4
+	case "slow-then-very-fast":
+		switch {
+		case n < 50:
+			return cube * (100 - n) / 50
+		case n < 68:
+			return cube * (150 - n) / 100
+		case n < 98:
+			return cube * ((1911 - 10*n) / 3) / 500
+		default:
+			return cube * (160 - n) / 100
+		}
+	case "fast-then-very-slow":
+		switch {
+		case n < 15:
+			return cube * ((n+1)/3 + 24) / 50
+		case n < 36:
+			return cube * (n + 14) / 50
+		default:
+			return cube * (n/2 + 32) / 50
+		}
+	default:
+		return cube
+	}
+}
+
+// LevelForExperience returns the level a pokemon with the given experience
+// has reached.
+func LevelForExperience(growthRate string, experience int) int {
+	level := MinLevel
+	for level < MaxLevel && ExperienceForLevel(growthRate, level+1) <= experience {
+		level++
+	}
+
+	return level
+}
+
+// ExperienceYield is the experience gained for defeating or catching a
+// pokemon with the given base experience at level.
+func ExperienceYield(baseExperience, level int) int {
+	return max(1, baseExperience*level/7)
+}
+
+// Stat calculates a stat at level from its base value, without individual
+// values, effort values or natures.
+func Stat(name string, base, level int) int {
+	if name == "hp" {
+		return 2*base*level/100 + level + 10
+	}
+
+	return 2*base*level/100 + 5
+}
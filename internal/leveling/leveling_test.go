@@ -0,0 +1,69 @@
+package leveling
+
+import "testing"
+
+func TestExperienceForLevel(t *testing.T) {
+	cases := []struct {
+		growthRate string
+		level      int
+		expected   int
+	}{
+		{"medium", 1, 0},
+		{"medium", 5, 125},
+		{"medium", 100, 1000000},
+		{"fast", 100, 800000},
+		{"slow", 100, 1250000},
+		{"medium-slow", 2, 9},
+		{"medium-slow", 100, 1059860},
+		{"slow-then-very-fast", 100, 600000},
+		{"fast-then-very-slow", 100, 1640000},
+		{"unknown", 10, 1000},
+	}
+
+	for _, c := range cases {
+		if actual := ExperienceForLevel(c.growthRate, c.level); actual != c.expected {
+			t.Errorf("%s at level %d: expected %d, got %d", c.growthRate, c.level, c.expected, actual)
+		}
+	}
+}
+
+func TestLevelForExperience(t *testing.T) {
+	cases := []struct {
+		growthRate string
+		experience int
+		expected   int
+	}{
+		{"medium", 0, 1},
+		{"medium", 124, 4},
+		{"medium", 125, 5},
+		{"medium", 5000000, 100},
+		{"medium-slow", 8, 1},
+		{"medium-slow", 9, 2},
+	}
+
+	for _, c := range cases {
+		if actual := LevelForExperience(c.growthRate, c.experience); actual != c.expected {
+			t.Errorf("%s with %d experience: expected level %d, got %d", c.growthRate, c.experience, c.expected, actual)
+		}
+	}
+}
+
+func TestStat(t *testing.T) {
+	cases := []struct {
+		name     string
+		base     int
+		level    int
+		expected int
+	}{
+		{"hp", 35, 50, 95},
+		{"hp", 35, 100, 180},
+		{"speed", 90, 50, 95},
+		{"attack", 55, 5, 10},
+	}
+
+	for _, c := range cases {
+		if actual := Stat(c.name, c.base, c.level); actual != c.expected {
+			t.Errorf("%s %d at level %d: expected %d, got %d", c.name, c.base, c.level, c.expected, actual)
+		}
+	}
+}
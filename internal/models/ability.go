@@ -0,0 +1,16 @@
+package models
+
+// AbilityDetails is an ability and the pokemons that can have it.
+type AbilityDetails struct {
+	ID       int              `json:"id"`
+	Name     string           `json:"name"`
+	Effect   string           `json:"effect"`
+	Pokemons []AbilityPokemon `json:"pokemons"`
+}
+
+// AbilityPokemon is a pokemon that can have an ability, as a hidden ability
+// or not.
+type AbilityPokemon struct {
+	Name   string `json:"name"`
+	Hidden bool   `json:"hidden"`
+}
@@ -1,11 +1,11 @@
 package models
 
 type Area struct {
-	Name string
-	Url  string
+	Name string `json:"name"`
+	Url  string `json:"url"`
 }
 
 type Pagination struct {
-	Next     *string
-	Previous *string
+	Next     *string `json:"next"`
+	Previous *string `json:"previous"`
 }
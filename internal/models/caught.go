@@ -0,0 +1,31 @@
+package models
+
+import "time"
+
+// CaughtPokemon is a pokemon in the Pokedex along with the state it gained
+// since it was caught.
+type CaughtPokemon struct {
+	Pokemon
+	// InstanceID tells apart pokemons of the same species, unlike the
+	// national dex ID of the embedded Pokemon.
+	InstanceID int       `json:"instance_id"`
+	Nickname   string    `json:"nickname,omitempty"`
+	Favorite   bool      `json:"favorite,omitempty"`
+	CaughtAt   time.Time `json:"caught_at,omitzero"`
+	CaughtIn   string    `json:"caught_in,omitempty"`
+	Level      int       `json:"level"`
+	Experience int       `json:"experience"`
+	GrowthRate string    `json:"growth_rate"`
+	// Outdated pokemons were stored by an older version and miss some of
+	// their details until they are fetched again.
+	Outdated bool `json:"outdated,omitempty"`
+}
+
+// DisplayName is the nickname of the pokemon, or its name when it has none.
+func (p CaughtPokemon) DisplayName() string {
+	if p.Nickname != "" {
+		return p.Nickname
+	}
+
+	return p.Name
+}
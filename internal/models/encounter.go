@@ -0,0 +1,27 @@
+package models
+
+// Encounter is one way of meeting a pokemon in a location area in a game
+// version. Chance is the percentage among the encounters of the same method.
+type Encounter struct {
+	Pokemon  string `json:"pokemon"`
+	Version  string `json:"version"`
+	Method   string `json:"method"`
+	Chance   int    `json:"chance"`
+	MinLevel int    `json:"min_level"`
+	MaxLevel int    `json:"max_level"`
+}
+
+// EncounterMethodRate is the percent chance per step of meeting any pokemon
+// with a method in a game version.
+type EncounterMethodRate struct {
+	Method  string `json:"method"`
+	Version string `json:"version"`
+	Rate    int    `json:"rate"`
+}
+
+// AreaEncounters are the wild pokemons of a location area.
+type AreaEncounters struct {
+	Area        string                `json:"area"`
+	Encounters  []Encounter           `json:"encounters"`
+	MethodRates []EncounterMethodRate `json:"method_rates"`
+}
@@ -0,0 +1,55 @@
+package models
+
+import (
+	"fmt"
+	"strings"
+)
+
+type EvolutionChain struct {
+	ID    int           `json:"id"`
+	Chain EvolutionNode `json:"chain"`
+}
+
+// EvolutionNode is a species in an evolution chain. Details describe the
+// alternative ways its parent evolves into it.
+type EvolutionNode struct {
+	Species   string            `json:"species"`
+	Details   []EvolutionDetail `json:"details,omitempty"`
+	EvolvesTo []EvolutionNode   `json:"evolves_to"`
+}
+
+type EvolutionDetail struct {
+	Trigger  string `json:"trigger"`
+	MinLevel int    `json:"min_level,omitempty"`
+	Item     string `json:"item,omitempty"`
+	// Conditions lists every other requirement in a human readable form.
+	Conditions []string `json:"conditions,omitempty"`
+}
+
+// Find returns the node of species within the tree rooted at n.
+func (n EvolutionNode) Find(species string) (EvolutionNode, bool) {
+	if n.Species == species {
+		return n, true
+	}
+
+	for _, next := range n.EvolvesTo {
+		if found, ok := next.Find(species); ok {
+			return found, true
+		}
+	}
+
+	return EvolutionNode{}, false
+}
+
+func (d EvolutionDetail) String() string {
+	parts := []string{d.Trigger}
+	if d.MinLevel > 0 {
+		parts = append(parts, fmt.Sprintf("level %d", d.MinLevel))
+	}
+	if d.Item != "" {
+		parts = append(parts, d.Item)
+	}
+	parts = append(parts, d.Conditions...)
+
+	return strings.Join(parts, ", ")
+}
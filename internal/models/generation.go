@@ -0,0 +1,8 @@
+package models
+
+type Generation struct {
+	ID      int      `json:"id"`
+	Name    string   `json:"name"`
+	Region  string   `json:"region"`
+	Species []string `json:"species"`
+}
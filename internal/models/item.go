@@ -0,0 +1,31 @@
+package models
+
+type Item struct {
+	ID       int    `json:"id"`
+	Name     string `json:"name"`
+	Cost     int    `json:"cost"`
+	Category string `json:"category"`
+	Effect   string `json:"effect"`
+}
+
+// HeldItem is an item a wild pokemon may hold, with the percent chance of it
+// holding the item in each game version.
+type HeldItem struct {
+	Name     string         `json:"name"`
+	Rarities map[string]int `json:"rarities"`
+}
+
+// Rarity returns the chance of holding the item in version, or the highest
+// chance across versions when version is empty.
+func (h HeldItem) Rarity(version string) int {
+	if version != "" {
+		return h.Rarities[version]
+	}
+
+	rarity := 0
+	for _, r := range h.Rarities {
+		rarity = max(rarity, r)
+	}
+
+	return rarity
+}
@@ -0,0 +1,17 @@
+package models
+
+type Move struct {
+	Name string `json:"name"`
+	Type string `json:"type"`
+	// Power is 0 for moves that deal no direct damage.
+	Power int `json:"power"`
+	// Accuracy is a percentage, 0 for moves that never miss.
+	Accuracy    int    `json:"accuracy"`
+	PP          int    `json:"pp"`
+	Priority    int    `json:"priority"`
+	DamageClass string `json:"damage_class"`
+	// EffectChance is the percentage chance of the effect of the move, 0
+	// when the effect always happens.
+	EffectChance int    `json:"effect_chance,omitempty"`
+	Effect       string `json:"effect,omitempty"`
+}
@@ -1,21 +1,104 @@
 package models
 
 type Pokemon struct {
-	Name           string
-	BaseExperience int
-	ID             int
-	Stats          []PokemonStat
-	Types          []string
-	Weight         int
-	Height         int
+	Name           string        `json:"name"`
+	BaseExperience int           `json:"base_experience"`
+	ID             int           `json:"id"`
+	Stats          []PokemonStat `json:"stats"`
+	Types          []string      `json:"types"`
+	Weight         int           `json:"weight"`
+	Height         int           `json:"height"`
+	Moves          []PokemonMove `json:"moves"`
+	Species        string        `json:"species"`
+	SpeciesUrl     string        `json:"species_url,omitempty"`
+	HeldItems      []HeldItem    `json:"held_items,omitempty"`
+	Abilities      []Ability     `json:"abilities,omitempty"`
+	Forms          []string      `json:"forms,omitempty"`
+	GameIndices    []GameIndex   `json:"game_indices,omitempty"`
+	Cries          Cries         `json:"cries,omitzero"`
+	Sprites        Sprites       `json:"sprites,omitzero"`
+}
+
+// SpeciesName falls back to the pokemon name for pokemons stored before the
+// species was recorded.
+func (p Pokemon) SpeciesName() string {
+	if p.Species != "" {
+		return p.Species
+	}
+
+	return p.Name
+}
+
+func (p Pokemon) BaseStatTotal() int {
+	total := 0
+	for _, stat := range p.Stats {
+		total += stat.BaseStat
+	}
+
+	return total
 }
 
 type PokemonStat struct {
-	Name     string
-	BaseStat int
+	Name     string `json:"name"`
+	BaseStat int    `json:"base_stat"`
+	// Effort is the effort value gained in the stat for defeating the pokemon.
+	Effort int `json:"effort,omitempty"`
+}
+
+type PokemonMove struct {
+	Name    string      `json:"name"`
+	Learned []MoveLearn `json:"learned,omitempty"`
+}
+
+// MoveLearn is how a pokemon learns a move in a version group. Level is 0
+// for methods other than level-up.
+type MoveLearn struct {
+	Method       string `json:"method"`
+	VersionGroup string `json:"version_group"`
+	Level        int    `json:"level"`
+}
+
+type Ability struct {
+	Name   string `json:"name"`
+	Hidden bool   `json:"hidden"`
+	Slot   int    `json:"slot"`
+}
+
+// GameIndex is the number of the pokemon in the dex of a game version.
+type GameIndex struct {
+	Version string `json:"version"`
+	Index   int    `json:"index"`
+}
+
+// Cries are the URLs of the pokemon's cry recordings.
+type Cries struct {
+	Latest string `json:"latest,omitempty"`
+	Legacy string `json:"legacy,omitempty"`
+}
+
+// Sprites are the URLs of the pokemon's default sprites.
+type Sprites struct {
+	Front      string `json:"front,omitempty"`
+	FrontShiny string `json:"front_shiny,omitempty"`
+	Back       string `json:"back,omitempty"`
+	BackShiny  string `json:"back_shiny,omitempty"`
+}
+
+// URL picks the sprite of the variant asked for.
+func (s Sprites) URL(shiny, back bool) string {
+	switch {
+	case shiny && back:
+		return s.BackShiny
+	case shiny:
+		return s.FrontShiny
+	case back:
+		return s.Back
+	default:
+		return s.Front
+	}
 }
 
 type PokemonShortInfo struct {
-	Name string
-	Url  string
+	Name string `json:"name"`
+	Url  string `json:"url"`
 }
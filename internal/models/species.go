@@ -0,0 +1,10 @@
+package models
+
+type Species struct {
+	ID                int    `json:"id"`
+	Name              string `json:"name"`
+	EvolvesFrom       string `json:"evolves_from,omitempty"`
+	EvolutionChainUrl string `json:"evolution_chain_url"`
+	GrowthRate        string `json:"growth_rate"`
+	CaptureRate       int    `json:"capture_rate"`
+}
@@ -0,0 +1,16 @@
+package models
+
+type Type struct {
+	Name            string          `json:"name"`
+	DamageRelations DamageRelations `json:"damage_relations"`
+	Pokemons        []string        `json:"pokemons"`
+}
+
+type DamageRelations struct {
+	DoubleDamageFrom []string `json:"double_damage_from"`
+	HalfDamageFrom   []string `json:"half_damage_from"`
+	NoDamageFrom     []string `json:"no_damage_from"`
+	DoubleDamageTo   []string `json:"double_damage_to"`
+	HalfDamageTo     []string `json:"half_damage_to"`
+	NoDamageTo       []string `json:"no_damage_to"`
+}
@@ -0,0 +1,53 @@
+package output
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+)
+
+type Format string
+
+const (
+	Text Format = "text"
+	JSON Format = "json"
+	YAML Format = "yaml"
+)
+
+func ParseFormat(s string) (Format, error) {
+	switch f := Format(s); f {
+	case Text, JSON, YAML:
+		return f, nil
+	default:
+		return "", fmt.Errorf("unknown output format %q, expected text, json or yaml", s)
+	}
+}
+
+// Error is the structured form of a failed command.
+type Error struct {
+	Code    string `json:"code"`
+	Message string `json:"message"`
+}
+
+type errorDocument struct {
+	Error Error `json:"error"`
+}
+
+// Write encodes v in a structured format. Text is not a structured format and
+// is rejected.
+func Write(w io.Writer, format Format, v any) error {
+	switch format {
+	case JSON:
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		return enc.Encode(v)
+	case YAML:
+		return WriteYAML(w, v)
+	default:
+		return fmt.Errorf("%s is not a structured output format", format)
+	}
+}
+
+func WriteError(w io.Writer, format Format, e Error) error {
+	return Write(w, format, errorDocument{Error: e})
+}
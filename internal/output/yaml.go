@@ -0,0 +1,182 @@
+package output
+
+import (
+	"encoding"
+	"fmt"
+	"io"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// WriteYAML encodes v as a YAML document. Struct fields are named after their
+// json tags so both structured formats share the same keys.
+func WriteYAML(w io.Writer, v any) error {
+	var b strings.Builder
+	b.WriteString("---\n")
+
+	value := reflect.ValueOf(v)
+	if scalar, ok := yamlScalar(value); ok {
+		b.WriteString(scalar + "\n")
+	} else {
+		writeYAMLNode(&b, value, 0)
+	}
+
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+type yamlField struct {
+	key   string
+	value reflect.Value
+}
+
+func writeYAMLNode(b *strings.Builder, v reflect.Value, indent int) {
+	v = indirect(v)
+	pad := strings.Repeat(" ", indent)
+
+	switch v.Kind() {
+	case reflect.Slice, reflect.Array:
+		for i := 0; i < v.Len(); i++ {
+			item := v.Index(i)
+			if scalar, ok := yamlScalar(item); ok {
+				fmt.Fprintf(b, "%s- %s\n", pad, scalar)
+				continue
+			}
+
+			var nested strings.Builder
+			writeYAMLNode(&nested, item, indent+2)
+			b.WriteString(pad + "- " + strings.TrimPrefix(nested.String(), pad+"  "))
+		}
+	default:
+		for _, f := range yamlFields(v) {
+			if scalar, ok := yamlScalar(f.value); ok {
+				fmt.Fprintf(b, "%s%s: %s\n", pad, f.key, scalar)
+				continue
+			}
+
+			fmt.Fprintf(b, "%s%s:\n", pad, f.key)
+			writeYAMLNode(b, f.value, indent+2)
+		}
+	}
+}
+
+func yamlFields(v reflect.Value) []yamlField {
+	var fields []yamlField
+
+	switch v.Kind() {
+	case reflect.Map:
+		for _, k := range v.MapKeys() {
+			fields = append(fields, yamlField{key: fmt.Sprint(k.Interface()), value: v.MapIndex(k)})
+		}
+		sort.Slice(fields, func(i, j int) bool {
+			return fields[i].key < fields[j].key
+		})
+	case reflect.Struct:
+		t := v.Type()
+		for i := 0; i < t.NumField(); i++ {
+			field := t.Field(i)
+			if !field.IsExported() {
+				continue
+			}
+
+			key := field.Name
+			omitEmpty := false
+			if tag, ok := field.Tag.Lookup("json"); ok {
+				name, opts, _ := strings.Cut(tag, ",")
+				if name == "-" {
+					continue
+				}
+				if name != "" {
+					key = name
+				}
+				omitEmpty = strings.Contains(opts, "omitempty") || strings.Contains(opts, "omitzero")
+			}
+
+			value := v.Field(i)
+			if omitEmpty && value.IsZero() {
+				continue
+			}
+			fields = append(fields, yamlField{key: key, value: value})
+		}
+	}
+
+	return fields
+}
+
+func indirect(v reflect.Value) reflect.Value {
+	for v.Kind() == reflect.Pointer || v.Kind() == reflect.Interface {
+		if v.IsNil() {
+			return v
+		}
+		v = v.Elem()
+	}
+
+	return v
+}
+
+// yamlScalar returns the inline representation of v. Empty collections are
+// written inline too.
+func yamlScalar(v reflect.Value) (string, bool) {
+	if !v.IsValid() {
+		return "null", true
+	}
+	if (v.Kind() == reflect.Pointer || v.Kind() == reflect.Interface) && v.IsNil() {
+		return "null", true
+	}
+	if m, ok := v.Interface().(encoding.TextMarshaler); ok {
+		text, err := m.MarshalText()
+		if err == nil {
+			return yamlString(string(text)), true
+		}
+	}
+
+	v = indirect(v)
+	switch v.Kind() {
+	case reflect.String:
+		return yamlString(v.String()), true
+	case reflect.Bool:
+		return strconv.FormatBool(v.Bool()), true
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return strconv.FormatInt(v.Int(), 10), true
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return strconv.FormatUint(v.Uint(), 10), true
+	case reflect.Float32, reflect.Float64:
+		return strconv.FormatFloat(v.Float(), 'g', -1, 64), true
+	case reflect.Slice, reflect.Array:
+		if v.Len() == 0 {
+			return "[]", true
+		}
+	case reflect.Map:
+		if v.Len() == 0 {
+			return "{}", true
+		}
+	case reflect.Struct:
+		if len(yamlFields(v)) == 0 {
+			return "{}", true
+		}
+	}
+
+	return "", false
+}
+
+func yamlString(s string) string {
+	if s == "" {
+		return `""`
+	}
+
+	switch strings.ToLower(s) {
+	case "null", "~", "true", "false", "yes", "no", "on", "off":
+		return strconv.Quote(s)
+	}
+	if _, err := strconv.ParseFloat(s, 64); err == nil {
+		return strconv.Quote(s)
+	}
+	if strings.ContainsAny(s, ":#{}[],&*!|>'\"%@`\n\t") || strings.HasPrefix(s, "-") || strings.HasPrefix(s, "?") ||
+		strings.TrimSpace(s) != s {
+		return strconv.Quote(s)
+	}
+
+	return s
+}
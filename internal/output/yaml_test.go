@@ -0,0 +1,82 @@
+package output
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestWriteYAML(t *testing.T) {
+	next := "https://pokeapi.co/api/v2/location-area?offset=20"
+
+	type stat struct {
+		Name     string `json:"name"`
+		BaseStat int    `json:"base_stat"`
+	}
+	type document struct {
+		Name     string         `json:"name"`
+		ID       int            `json:"id"`
+		Caught   bool           `json:"caught"`
+		Stats    []stat         `json:"stats"`
+		Types    []string       `json:"types"`
+		Next     *string        `json:"next"`
+		Previous *string        `json:"previous"`
+		Counts   map[string]int `json:"counts"`
+		Empty    []string       `json:"empty"`
+		Skipped  string         `json:"skipped,omitempty"`
+	}
+
+	cases := []struct {
+		input    any
+		expected string
+	}{
+		{
+			input: document{
+				Name:   "mr-mime",
+				ID:     122,
+				Caught: true,
+				Stats:  []stat{{Name: "hp", BaseStat: 40}, {Name: "speed", BaseStat: 90}},
+				Types:  []string{"psychic", "fairy"},
+				Next:   &next,
+				Counts: map[string]int{"seen": 2, "caught": 1},
+			},
+			expected: `---
+name: mr-mime
+id: 122
+caught: true
+stats:
+  - name: hp
+    base_stat: 40
+  - name: speed
+    base_stat: 90
+types:
+  - psychic
+  - fairy
+next: "https://pokeapi.co/api/v2/location-area?offset=20"
+previous: null
+counts:
+  caught: 1
+  seen: 2
+empty: []
+`,
+		},
+		{
+			input:    []string{"yes", "007", ""},
+			expected: "---\n- \"yes\"\n- \"007\"\n- \"\"\n",
+		},
+		{
+			input:    "pikachu",
+			expected: "---\npikachu\n",
+		},
+	}
+
+	for _, c := range cases {
+		var b strings.Builder
+		if err := WriteYAML(&b, c.input); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if b.String() != c.expected {
+			t.Errorf("unexpected yaml:\nexpected:\n%s\nactual:\n%s", c.expected, b.String())
+		}
+	}
+}
@@ -0,0 +1,247 @@
+// Package pokeapitest provides a fake PokeAPI server for tests.
+package pokeapitest
+
+import (
+	"bytes"
+	"embed"
+	"encoding/json"
+	"fmt"
+	"image"
+	"image/color"
+	"image/png"
+	"io/fs"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/NeriusZar/pokedexcli/internal/api"
+)
+
+const apiPath = "/api/v2"
+const pageSize = 20
+
+// baseUrlPlaceholder is replaced with the URL of the running server in every
+// served response, so URLs inside fixtures point back at the fake.
+const baseUrlPlaceholder = "{{base}}"
+
+//go:embed fixtures/*.json
+var fixtures embed.FS
+
+type Server struct {
+	*httptest.Server
+
+	mu       sync.Mutex
+	areas    []string
+	routes   map[string][]byte
+	requests map[string]int
+}
+
+// NewServer starts a fake PokeAPI serving the default fixtures. The caller
+// must Close it when done.
+func NewServer() *Server {
+	s := &Server{
+		routes:   map[string][]byte{},
+		requests: map[string]int{},
+	}
+
+	entries, err := fs.Glob(fixtures, "fixtures/*.json")
+	if err != nil {
+		panic(err)
+	}
+	for _, entry := range entries {
+		data, err := fixtures.ReadFile(entry)
+		if err != nil {
+			panic(err)
+		}
+
+		name := strings.TrimSuffix(strings.TrimPrefix(entry, "fixtures/"), ".json")
+		kind, resource, _ := strings.Cut(name, "_")
+		switch kind {
+		case "area":
+			s.areas = append(s.areas, resource)
+			s.routes[apiPath+"/location-area/"+resource] = data
+		case "pokemon", "pokemon-species":
+			s.routes[apiPath+"/"+kind+"/"+resource] = data
+			// PokeAPI serves pokemons and species by national dex ID as
+			// well.
+			var ref struct {
+				ID int `json:"id"`
+			}
+			if err := json.Unmarshal(data, &ref); err != nil {
+				panic(err)
+			}
+			s.routes[apiPath+"/"+kind+"/"+strconv.Itoa(ref.ID)] = data
+		default:
+			s.routes[apiPath+"/"+kind+"/"+resource] = data
+		}
+	}
+
+	for i := len(s.areas) + 1; i <= 25; i++ {
+		s.areas = append(s.areas, fmt.Sprintf("route-%d-area", i))
+	}
+
+	s.Server = httptest.NewServer(http.HandlerFunc(s.handle))
+	return s
+}
+
+// BaseUrl is the PokeAPI base URL to configure clients with.
+func (s *Server) BaseUrl() string {
+	return s.URL + apiPath
+}
+
+// Requests returns how many times path was requested.
+func (s *Server) Requests(path string) int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.requests[apiPath+path]
+}
+
+func (s *Server) AddArea(area api.AreaDetailsResponse) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.areas = append(s.areas, area.Name)
+	s.routes[apiPath+"/location-area/"+area.Name] = mustMarshal(area)
+}
+
+func (s *Server) AddPokemon(pokemon api.PokemonDetailsResponse) {
+	s.set("/pokemon/"+pokemon.Name, pokemon)
+}
+
+func (s *Server) AddMove(move api.MoveResponse) {
+	s.set("/move/"+move.Name, move)
+}
+
+func (s *Server) set(path string, v any) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.routes[apiPath+path] = mustMarshal(v)
+}
+
+func (s *Server) handle(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	path := strings.TrimSuffix(r.URL.Path, "/")
+	s.requests[path]++
+
+	if strings.HasPrefix(path, "/sprites/") {
+		w.Header().Set("Content-Type", "image/png")
+		w.Write(sprite(strings.Contains(path, "/shiny/")))
+		return
+	}
+
+	var body []byte
+	if path == apiPath+"/location-area" {
+		body = s.areaPage(r)
+	} else if data, ok := s.routes[path]; ok {
+		body = data
+	} else if list, ok := s.list(path); ok {
+		body = list
+	} else {
+		http.NotFound(w, r)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.Write([]byte(strings.ReplaceAll(string(body), baseUrlPlaceholder, s.URL)))
+}
+
+func (s *Server) areaPage(r *http.Request) []byte {
+	offset, _ := strconv.Atoi(r.URL.Query().Get("offset"))
+	limit, err := strconv.Atoi(r.URL.Query().Get("limit"))
+	if err != nil || limit <= 0 {
+		limit = pageSize
+	}
+	offset = max(0, min(offset, len(s.areas)))
+	end := min(offset+limit, len(s.areas))
+
+	page := api.AreaResponse{Count: len(s.areas)}
+	for _, name := range s.areas[offset:end] {
+		page.Results = append(page.Results, struct {
+			Name string `json:"name"`
+			URL  string `json:"url"`
+		}{
+			Name: name,
+			URL:  baseUrlPlaceholder + apiPath + "/location-area/" + name + "/",
+		})
+	}
+
+	pageUrl := func(offset int) *string {
+		url := fmt.Sprintf("%s%s/location-area?offset=%d&limit=%d", baseUrlPlaceholder, apiPath, offset, limit)
+		return &url
+	}
+	if end < len(s.areas) {
+		page.Next = pageUrl(end)
+	}
+	if offset > 0 {
+		page.Previous = pageUrl(max(0, offset-limit))
+	}
+
+	return mustMarshal(page)
+}
+
+// list returns every resource under path in a single page, like PokeAPI does
+// for lists requested with a large enough limit.
+func (s *Server) list(path string) ([]byte, bool) {
+	prefix := path + "/"
+	var names []string
+	for route := range s.routes {
+		if name, ok := strings.CutPrefix(route, prefix); ok && !strings.Contains(name, "/") {
+			names = append(names, name)
+		}
+	}
+	if len(names) == 0 {
+		return nil, false
+	}
+	slices.Sort(names)
+
+	page := api.AreaResponse{Count: len(names)}
+	for _, name := range names {
+		page.Results = append(page.Results, struct {
+			Name string `json:"name"`
+			URL  string `json:"url"`
+		}{
+			Name: name,
+			URL:  baseUrlPlaceholder + prefix + name + "/",
+		})
+	}
+
+	return mustMarshal(page), true
+}
+
+func mustMarshal(v any) []byte {
+	data, err := json.Marshal(v)
+	if err != nil {
+		panic(err)
+	}
+
+	return data
+}
+
+// sprite is served for every sprite URL: a red row over a blue one inside a
+// transparent border, or yellow over blue for shiny sprites.
+func sprite(shiny bool) []byte {
+	top := color.NRGBA{R: 255, A: 255}
+	if shiny {
+		top = color.NRGBA{R: 255, G: 255, A: 255}
+	}
+
+	img := image.NewNRGBA(image.Rect(0, 0, 4, 4))
+	for x := 1; x <= 2; x++ {
+		img.Set(x, 1, top)
+		img.Set(x, 2, color.NRGBA{B: 255, A: 255})
+	}
+
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, img); err != nil {
+		panic(err)
+	}
+
+	return buf.Bytes()
+}
@@ -0,0 +1,109 @@
+package pokecache
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/NeriusZar/pokedexcli/internal/storage"
+)
+
+const diskEntryExt = ".cache"
+
+type diskStore struct {
+	dir      string
+	ttl      time.Duration
+	maxBytes int64
+}
+
+func (d *diskStore) path(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return filepath.Join(d.dir, hex.EncodeToString(sum[:])+diskEntryExt)
+}
+
+func (d *diskStore) get(key string, now time.Time) ([]byte, bool) {
+	path := d.path(key)
+
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, false
+	}
+	if now.Sub(info.ModTime()) > d.ttl {
+		os.Remove(path)
+		return nil, false
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, false
+	}
+
+	return data, true
+}
+
+func (d *diskStore) add(key string, val []byte) error {
+	if err := storage.WriteFileAtomic(d.path(key), val); err != nil {
+		return err
+	}
+
+	return d.prune()
+}
+
+// prune removes the oldest entries until the directory fits into maxBytes.
+func (d *diskStore) prune() error {
+	if d.maxBytes <= 0 {
+		return nil
+	}
+
+	dirEntries, err := os.ReadDir(d.dir)
+	if err != nil {
+		return err
+	}
+
+	type fileInfo struct {
+		path    string
+		size    int64
+		modTime time.Time
+	}
+
+	var files []fileInfo
+	var total int64
+	for _, e := range dirEntries {
+		if e.IsDir() || !strings.HasSuffix(e.Name(), diskEntryExt) {
+			continue
+		}
+		info, err := e.Info()
+		if err != nil {
+			continue
+		}
+		files = append(files, fileInfo{
+			path:    filepath.Join(d.dir, e.Name()),
+			size:    info.Size(),
+			modTime: info.ModTime(),
+		})
+		total += info.Size()
+	}
+
+	sort.Slice(files, func(i, j int) bool {
+		return files[i].modTime.Before(files[j].modTime)
+	})
+
+	for _, f := range files {
+		if total <= d.maxBytes {
+			break
+		}
+		// Another prune may have removed it already.
+		if err := os.Remove(f.path); err != nil && !errors.Is(err, fs.ErrNotExist) {
+			return err
+		}
+		total -= f.size
+	}
+
+	return nil
+}
@@ -8,6 +8,7 @@ import (
 type Cache struct {
 	cacheEntries map[string]cacheEntry
 	mu           *sync.Mutex
+	disk         *diskStore
 }
 
 type cacheEntry struct {
@@ -26,27 +27,63 @@ func NewCache(interval time.Duration) Cache {
 	return cache
 }
 
+// NewPersistentCache returns a Cache that keeps an on-disk copy of every entry
+// in dir. Entries evicted from memory are read back from disk until they are
+// older than ttl. The directory is trimmed to maxBytes, oldest entries first.
+func NewPersistentCache(interval time.Duration, dir string, ttl time.Duration, maxBytes int64) Cache {
+	cache := NewCache(interval)
+	cache.disk = &diskStore{
+		dir:      dir,
+		ttl:      ttl,
+		maxBytes: maxBytes,
+	}
+
+	return cache
+}
+
+// Add stores val in memory and, for persistent caches, on disk. The disk is
+// written without holding the lock, so lookups don't wait on it.
 func (c Cache) Add(key string, val []byte) error {
 	c.mu.Lock()
-	defer c.mu.Unlock()
-
 	c.cacheEntries[key] = cacheEntry{
 		data:      val,
 		createdAt: time.Now(),
 	}
+	c.mu.Unlock()
+
+	if c.disk != nil {
+		return c.disk.add(key, val)
+	}
 
 	return nil
 }
 
 func (c Cache) Get(key string) ([]byte, bool) {
 	c.mu.Lock()
-	defer c.mu.Unlock()
 	val, ok := c.cacheEntries[key]
+	c.mu.Unlock()
+	if ok {
+		return val.data, ok
+	}
+
+	if c.disk == nil {
+		return nil, false
+	}
+
+	now := time.Now()
+	data, ok := c.disk.get(key, now)
 	if !ok {
-		return nil, ok
+		return nil, false
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.cacheEntries[key] = cacheEntry{
+		data:      data,
+		createdAt: now,
 	}
 
-	return val.data, ok
+	return data, true
 }
 
 func (c Cache) reapLoop(interval time.Duration) {
@@ -2,6 +2,7 @@ package pokecache
 
 import (
 	"fmt"
+	"sync"
 	"testing"
 	"time"
 )
@@ -84,3 +85,69 @@ func TestMultipleAdds(t *testing.T) {
 		}
 	}
 }
+
+func TestPersistentCacheSurvivesNewInstance(t *testing.T) {
+	dir := t.TempDir()
+	cache := NewPersistentCache(5*time.Second, dir, time.Hour, 0)
+	cache.Add("https://example.com", []byte("testdata"))
+
+	fresh := NewPersistentCache(5*time.Second, dir, time.Hour, 0)
+	val, ok := fresh.Get("https://example.com")
+	if !ok {
+		t.Errorf("expected to find key on disk")
+		return
+	}
+	if string(val) != "testdata" {
+		t.Errorf("expected to find value")
+		return
+	}
+}
+
+func TestPersistentCacheExpiresEntries(t *testing.T) {
+	const baseTime = 5 * time.Millisecond
+	dir := t.TempDir()
+	cache := NewPersistentCache(baseTime, dir, baseTime, 0)
+	cache.Add("https://example.com", []byte("testdata"))
+
+	time.Sleep(baseTime + 5*time.Millisecond)
+
+	_, ok := cache.Get("https://example.com")
+	if ok {
+		t.Errorf("expected to not find key")
+		return
+	}
+}
+
+func TestPersistentCacheSizeCap(t *testing.T) {
+	dir := t.TempDir()
+	cache := NewPersistentCache(5*time.Second, dir, time.Hour, 10)
+	cache.Add("https://example.com/1", []byte("12345678"))
+	time.Sleep(10 * time.Millisecond)
+	cache.Add("https://example.com/2", []byte("12345678"))
+
+	fresh := NewPersistentCache(5*time.Second, dir, time.Hour, 10)
+	if _, ok := fresh.Get("https://example.com/1"); ok {
+		t.Errorf("expected oldest entry to be evicted")
+	}
+	if _, ok := fresh.Get("https://example.com/2"); !ok {
+		t.Errorf("expected newest entry to be kept")
+	}
+}
+
+func TestPersistentCacheConcurrentUse(t *testing.T) {
+	cache := NewPersistentCache(5*time.Second, t.TempDir(), time.Hour, 64)
+
+	var wg sync.WaitGroup
+	for i := range 8 {
+		wg.Go(func() {
+			key := fmt.Sprintf("https://example.com/%d", i)
+			if err := cache.Add(key, []byte("12345678")); err != nil {
+				t.Errorf("unexpected error adding %s: %v", key, err)
+			}
+			if _, ok := cache.Get(key); !ok {
+				t.Errorf("expected to find %s", key)
+			}
+		})
+	}
+	wg.Wait()
+}
@@ -1,46 +1,231 @@
 package pokedex
 
 import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"maps"
+	"slices"
+	"strconv"
+	"strings"
 	"sync"
 
 	"github.com/NeriusZar/pokedexcli/internal/models"
 )
 
+var (
+	ErrNotCaught = errors.New("you have not caught that pokemon")
+	ErrAmbiguous = errors.New("more than one caught pokemon matches")
+)
+
+// Pokedex holds the caught pokemons by instance ID.
 type Pokedex struct {
-	pokemons map[string]models.Pokemon
+	pokemons map[int]models.CaughtPokemon
+	nextID   *int
 	mu       *sync.Mutex
+	path     string
 }
 
 func NewPokedex() Pokedex {
+	nextID := 1
 	return Pokedex{
-		pokemons: map[string]models.Pokemon{},
+		pokemons: map[int]models.CaughtPokemon{},
+		nextID:   &nextID,
 		mu:       &sync.Mutex{},
 	}
 }
 
-func (p Pokedex) Add(pokemon models.Pokemon) {
+// Open returns a Pokedex backed by the file at path. Existing contents are
+// loaded and every change is written back to the file.
+func Open(path string) (Pokedex, error) {
+	p := NewPokedex()
+	p.path = path
+
+	file, err := readFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return p, nil
+	}
+	if err != nil {
+		return Pokedex{}, fmt.Errorf("failed to load %s: %w", path, err)
+	}
+
+	p.fill(file)
+
+	return p, nil
+}
+
+func (p Pokedex) Path() string {
+	return p.path
+}
+
+// Add stores pokemon under a new instance ID and returns it as stored.
+func (p Pokedex) Add(pokemon models.CaughtPokemon) (models.CaughtPokemon, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	pokemon.InstanceID = *p.nextID
+	*p.nextID++
+	p.pokemons[pokemon.InstanceID] = pokemon
+
+	return pokemon, p.persist()
+}
+
+// Replace stores each pokemon in place of the pokemon with the same instance
+// ID, e.g. after it evolved, and saves them at once.
+func (p Pokedex) Replace(pokemons ...models.CaughtPokemon) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	for _, pokemon := range pokemons {
+		if _, ok := p.pokemons[pokemon.InstanceID]; !ok {
+			return fmt.Errorf("#%d is not in the pokedex", pokemon.InstanceID)
+		}
+	}
+	for _, pokemon := range pokemons {
+		p.pokemons[pokemon.InstanceID] = pokemon
+	}
+
+	return p.persist()
+}
+
+// Update applies change to the pokemon with the instance ID and stores the
+// result. The instance ID can't be changed.
+func (p Pokedex) Update(id int, change func(*models.CaughtPokemon)) (models.CaughtPokemon, error) {
 	p.mu.Lock()
 	defer p.mu.Unlock()
 
-	p.pokemons[pokemon.Name] = pokemon
+	pokemon, ok := p.pokemons[id]
+	if !ok {
+		return models.CaughtPokemon{}, fmt.Errorf("%w: #%d", ErrNotCaught, id)
+	}
+
+	change(&pokemon)
+	pokemon.InstanceID = id
+	p.pokemons[id] = pokemon
+
+	return pokemon, p.persist()
 }
 
-func (p Pokedex) Get(name string) (models.Pokemon, bool) {
+// Remove takes the pokemon with the instance ID out of the Pokedex. Its ID is
+// not reused while the Pokedex is open.
+func (p Pokedex) Remove(id int) (models.CaughtPokemon, error) {
 	p.mu.Lock()
 	defer p.mu.Unlock()
 
-	pokemon, ok := p.pokemons[name]
-	return pokemon, ok
+	pokemon, ok := p.pokemons[id]
+	if !ok {
+		return models.CaughtPokemon{}, fmt.Errorf("%w: #%d", ErrNotCaught, id)
+	}
+
+	delete(p.pokemons, id)
+
+	return pokemon, p.persist()
 }
 
-func (p Pokedex) GetAll() []models.Pokemon {
+// Find returns the pokemon ref refers to: an instance ID, optionally prefixed
+// with "#", or the only pokemon nicknamed ref or of species ref. A nickname
+// that is also the name of a caught species is ambiguous.
+func (p Pokedex) Find(ref string) (models.CaughtPokemon, error) {
 	p.mu.Lock()
 	defer p.mu.Unlock()
 
-	pokemons := make([]models.Pokemon, 0, len(p.pokemons))
-	for _, v := range p.pokemons {
-		pokemons = append(pokemons, v)
+	if id, err := strconv.Atoi(strings.TrimPrefix(ref, "#")); err == nil {
+		pokemon, ok := p.pokemons[id]
+		if !ok {
+			return models.CaughtPokemon{}, ErrNotCaught
+		}
+		return pokemon, nil
+	}
+
+	var matches []models.CaughtPokemon
+	for _, pokemon := range p.all() {
+		if pokemon.Name == ref || pokemon.Nickname == ref {
+			matches = append(matches, pokemon)
+		}
+	}
+
+	switch len(matches) {
+	case 0:
+		return models.CaughtPokemon{}, ErrNotCaught
+	case 1:
+		return matches[0], nil
+	default:
+		ids := make([]string, len(matches))
+		for i, m := range matches {
+			ids[i] = "#" + strconv.Itoa(m.InstanceID)
+		}
+		return models.CaughtPokemon{}, fmt.Errorf("%w: %s names %d pokemons, pick one of %s", ErrAmbiguous, ref, len(matches), strings.Join(ids, ", "))
+	}
+}
+
+// Get is Find for callers that only care whether ref matched a pokemon.
+func (p Pokedex) Get(ref string) (models.CaughtPokemon, bool) {
+	pokemon, err := p.Find(ref)
+	return pokemon, err == nil
+}
+
+func (p Pokedex) GetAll() []models.CaughtPokemon {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	return p.all()
+}
+
+// Save writes the Pokedex to path, independently of the file it is backed by.
+func (p Pokedex) Save(path string) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	return writeFile(path, *p.nextID, p.all())
+}
+
+// Load replaces the contents of the Pokedex with the ones stored at path.
+func (p Pokedex) Load(path string) error {
+	file, err := readFile(path)
+	if err != nil {
+		return fmt.Errorf("failed to load %s: %w", path, err)
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	clear(p.pokemons)
+	p.fill(file)
+
+	return p.persist()
+}
+
+// fill stores the pokemons of file by their instance ID, giving a new one to
+// pokemons without an ID or with one already taken.
+func (p Pokedex) fill(file pokedexFile) {
+	*p.nextID = max(1, file.NextID)
+	for _, pokemon := range file.Pokemons {
+		*p.nextID = max(*p.nextID, pokemon.InstanceID+1)
+	}
+
+	for _, pokemon := range file.Pokemons {
+		if _, taken := p.pokemons[pokemon.InstanceID]; pokemon.InstanceID < 1 || taken {
+			pokemon.InstanceID = *p.nextID
+			*p.nextID++
+		}
+		p.pokemons[pokemon.InstanceID] = pokemon
+	}
+}
+
+// all returns the pokemons in the order they were caught.
+func (p Pokedex) all() []models.CaughtPokemon {
+	pokemons := make([]models.CaughtPokemon, 0, len(p.pokemons))
+	for _, id := range slices.Sorted(maps.Keys(p.pokemons)) {
+		pokemons = append(pokemons, p.pokemons[id])
 	}
 
 	return pokemons
 }
+
+func (p Pokedex) persist() error {
+	if p.path == "" {
+		return nil
+	}
+
+	return writeFile(p.path, *p.nextID, p.all())
+}
@@ -0,0 +1,334 @@
+package pokedex
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/NeriusZar/pokedexcli/internal/models"
+)
+
+func caught(name string, id int) models.CaughtPokemon {
+	return models.CaughtPokemon{
+		Pokemon: models.Pokemon{Name: name, ID: id},
+		Level:   5,
+	}
+}
+
+func TestOpenPersistsAdds(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "pokedex.json")
+
+	p, err := Open(path)
+	if err != nil {
+		t.Fatalf("unexpected error opening empty pokedex: %v", err)
+	}
+
+	pikachu := models.CaughtPokemon{
+		Pokemon: models.Pokemon{
+			Name:  "pikachu",
+			ID:    25,
+			Stats: []models.PokemonStat{{Name: "hp", BaseStat: 35}},
+			Types: []string{"electric"},
+		},
+		Level:      12,
+		Experience: 1728,
+		GrowthRate: "medium",
+	}
+	if _, err := p.Add(pikachu); err != nil {
+		t.Fatalf("unexpected error adding pokemon: %v", err)
+	}
+
+	reopened, err := Open(path)
+	if err != nil {
+		t.Fatalf("unexpected error reopening pokedex: %v", err)
+	}
+
+	got, ok := reopened.Get("pikachu")
+	if !ok {
+		t.Fatalf("expected to find pikachu after reopening")
+	}
+	if got.ID != 25 || len(got.Stats) != 1 || got.Types[0] != "electric" || got.Level != 12 {
+		t.Errorf("reloaded pokemon does not match: %+v", got)
+	}
+}
+
+func TestOpenRejectsBadFiles(t *testing.T) {
+	cases := []struct {
+		name     string
+		contents string
+		expected error
+	}{
+		{
+			name:     "corrupt",
+			contents: `{"version": 1, "pokemons": [`,
+			expected: ErrCorruptFile,
+		},
+		{
+			name:     "missing version",
+			contents: `{"pokemons": []}`,
+			expected: ErrUnsupportedVersion,
+		},
+		{
+			name:     "newer version",
+			contents: `{"version": 99, "pokemons": []}`,
+			expected: ErrUnsupportedVersion,
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "pokedex.json")
+			if err := os.WriteFile(path, []byte(c.contents), 0o644); err != nil {
+				t.Fatal(err)
+			}
+
+			_, err := Open(path)
+			if !errors.Is(err, c.expected) {
+				t.Errorf("expected %v, got %v", c.expected, err)
+			}
+
+			data, _ := os.ReadFile(path)
+			if string(data) != c.contents {
+				t.Errorf("expected the file to be left untouched")
+			}
+		})
+	}
+}
+
+func TestSaveLoad(t *testing.T) {
+	dir := t.TempDir()
+	exported := filepath.Join(dir, "export.json")
+
+	source := NewPokedex()
+	source.Add(caught("bulbasaur", 1))
+	source.Add(caught("squirtle", 7))
+	if err := source.Save(exported); err != nil {
+		t.Fatalf("unexpected error saving: %v", err)
+	}
+
+	target, err := Open(filepath.Join(dir, "pokedex.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	target.Add(caught("charmander", 4))
+
+	if err := target.Load(exported); err != nil {
+		t.Fatalf("unexpected error loading: %v", err)
+	}
+
+	if len(target.GetAll()) != 2 {
+		t.Errorf("expected 2 pokemons after load, got %d", len(target.GetAll()))
+	}
+	if _, ok := target.Get("charmander"); ok {
+		t.Errorf("expected load to replace existing pokemons")
+	}
+}
+
+func TestOpenMigratesVersion1(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "pokedex.json")
+	contents := `{"version": 1, "pokemons": [{"name": "pidgey", "id": 16, "base_experience": 50}]}`
+	if err := os.WriteFile(path, []byte(contents), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	p, err := Open(path)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	pidgey, ok := p.Get("pidgey")
+	if !ok {
+		t.Fatalf("expected to find pidgey")
+	}
+	if pidgey.ID != 16 || pidgey.BaseExperience != 50 {
+		t.Errorf("expected the pokemon data to be kept, got %+v", pidgey)
+	}
+	if pidgey.Level != DefaultLevel || pidgey.Experience != 125 {
+		t.Errorf("expected the default level, got level %d with %d experience", pidgey.Level, pidgey.Experience)
+	}
+}
+
+func TestOpenMigratesVersion2(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "pokedex.json")
+	contents := `{"version": 2, "pokemons": [
+		{"name": "pidgey", "id": 16, "level": 7, "experience": 343, "growth_rate": "medium-slow"},
+		{"name": "pikachu", "id": 25, "level": 5, "experience": 125, "growth_rate": "medium"}
+	]}`
+	if err := os.WriteFile(path, []byte(contents), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	p, err := Open(path)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	pikachu, err := p.Find("#2")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if pikachu.Name != "pikachu" || pikachu.Level != 5 {
+		t.Errorf("expected pikachu as #2, got %+v", pikachu)
+	}
+	if !pikachu.CaughtAt.IsZero() || pikachu.CaughtIn != "" {
+		t.Errorf("expected no catch details for migrated pokemons, got %+v", pikachu)
+	}
+
+	added, err := p.Add(caught("pidgey", 16))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if added.InstanceID != 3 {
+		t.Errorf("expected the next pokemon to be #3, got #%d", added.InstanceID)
+	}
+}
+
+func TestOpenMarksVersion3Outdated(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "pokedex.json")
+	contents := `{"version": 3, "pokemons": [{"name": "pidgey", "id": 16, "instance_id": 1, "level": 7}]}`
+	if err := os.WriteFile(path, []byte(contents), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	p, err := Open(path)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	pidgey, err := p.Find("pidgey")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !pidgey.Outdated || pidgey.Level != 7 {
+		t.Errorf("expected pidgey to be kept and marked outdated, got %+v", pidgey)
+	}
+}
+
+func TestMultipleOfASpecies(t *testing.T) {
+	p := NewPokedex()
+	first, _ := p.Add(caught("pidgey", 16))
+	p.Add(caught("pikachu", 25))
+	second, _ := p.Add(caught("pidgey", 16))
+
+	if first.InstanceID == second.InstanceID {
+		t.Fatalf("expected distinct instance IDs, got #%d twice", first.InstanceID)
+	}
+	if len(p.GetAll()) != 3 {
+		t.Errorf("expected the second pidgey to be kept, got %d pokemons", len(p.GetAll()))
+	}
+
+	cases := []struct {
+		ref      string
+		expected int
+		err      error
+	}{
+		{"pikachu", 2, nil},
+		{"3", second.InstanceID, nil},
+		{"#1", first.InstanceID, nil},
+		{"pidgey", 0, ErrAmbiguous},
+		{"raichu", 0, ErrNotCaught},
+		{"#9", 0, ErrNotCaught},
+	}
+
+	for _, c := range cases {
+		pokemon, err := p.Find(c.ref)
+		if !errors.Is(err, c.err) {
+			t.Errorf("%s: expected error %v, got %v", c.ref, c.err, err)
+			continue
+		}
+		if pokemon.InstanceID != c.expected {
+			t.Errorf("%s: expected #%d, got #%d", c.ref, c.expected, pokemon.InstanceID)
+		}
+	}
+
+	evolved := second
+	evolved.Name = "pidgeotto"
+	if err := p.Replace(evolved); err != nil {
+		t.Fatal(err)
+	}
+	if got, err := p.Find("pidgey"); err != nil || got.InstanceID != first.InstanceID {
+		t.Errorf("expected the remaining pidgey to be #%d, got #%d (%v)", first.InstanceID, got.InstanceID, err)
+	}
+}
+
+func TestUpdateAndRemove(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "pokedex.json")
+	p, err := Open(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	pidgey, _ := p.Add(caught("pidgey", 16))
+	p.Add(caught("pidgey", 16))
+	pikachu, _ := p.Add(caught("pikachu", 25))
+
+	if _, err := p.Update(pidgey.InstanceID, func(c *models.CaughtPokemon) {
+		c.Nickname = "birdie"
+		c.InstanceID = 42
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	reopened, err := Open(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	birdie, err := reopened.Find("birdie")
+	if err != nil {
+		t.Fatalf("expected to find pidgey by its nickname: %v", err)
+	}
+	if birdie.InstanceID != pidgey.InstanceID {
+		t.Errorf("expected the update to keep the instance ID #%d, got #%d", pidgey.InstanceID, birdie.InstanceID)
+	}
+
+	if _, err := reopened.Remove(pikachu.InstanceID); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := reopened.Remove(pikachu.InstanceID); !errors.Is(err, ErrNotCaught) {
+		t.Errorf("expected removing twice to fail with ErrNotCaught, got %v", err)
+	}
+	if added, _ := reopened.Add(caught("raichu", 26)); added.InstanceID == pikachu.InstanceID {
+		t.Errorf("expected the released ID #%d not to be reused", pikachu.InstanceID)
+	}
+	if _, err := reopened.Update(99, func(c *models.CaughtPokemon) {}); !errors.Is(err, ErrNotCaught) {
+		t.Errorf("expected updating a missing pokemon to fail with ErrNotCaught, got %v", err)
+	}
+}
+
+func TestReleasedIDsAreNotReusedAfterReopening(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "pokedex.json")
+	p, err := Open(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	p.Add(caught("pidgey", 16))
+	pikachu, _ := p.Add(caught("pikachu", 25))
+	if _, err := p.Remove(pikachu.InstanceID); err != nil {
+		t.Fatal(err)
+	}
+
+	reopened, err := Open(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if added, _ := reopened.Add(caught("raichu", 26)); added.InstanceID == pikachu.InstanceID {
+		t.Errorf("expected the released ID #%d not to be reused after reopening", pikachu.InstanceID)
+	}
+}
+
+func TestNicknameOfAnotherSpecies(t *testing.T) {
+	p := NewPokedex()
+	tentacool, _ := p.Add(caught("tentacool", 72))
+	if _, err := p.Update(tentacool.InstanceID, func(c *models.CaughtPokemon) { c.Nickname = "pikachu" }); err != nil {
+		t.Fatal(err)
+	}
+
+	if got, err := p.Find("pikachu"); err != nil || got.InstanceID != tentacool.InstanceID {
+		t.Errorf("expected the nickname to find #%d, got #%d (%v)", tentacool.InstanceID, got.InstanceID, err)
+	}
+
+	p.Add(caught("pikachu", 25))
+	if _, err := p.Find("pikachu"); !errors.Is(err, ErrAmbiguous) {
+		t.Errorf("expected a nickname and a species of the same name to be ambiguous, got %v", err)
+	}
+}
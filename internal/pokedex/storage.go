@@ -0,0 +1,147 @@
+package pokedex
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/NeriusZar/pokedexcli/internal/leveling"
+	"github.com/NeriusZar/pokedexcli/internal/models"
+	"github.com/NeriusZar/pokedexcli/internal/storage"
+)
+
+const fileVersion = 5
+
+// DefaultLevel is the level given to pokemons that were caught before levels
+// were tracked.
+const DefaultLevel = 5
+
+var (
+	ErrCorruptFile        = errors.New("pokedex file is corrupt")
+	ErrUnsupportedVersion = errors.New("pokedex file version is not supported")
+)
+
+type pokedexFile struct {
+	Version int `json:"version"`
+	// NextID is the instance ID of the next pokemon, so released IDs are
+	// never reused.
+	NextID   int                    `json:"next_id,omitempty"`
+	Pokemons []models.CaughtPokemon `json:"pokemons"`
+}
+
+type versionHeader struct {
+	Version int `json:"version"`
+}
+
+// migrations upgrade every pokemon of a file written with version N to
+// version N+1, given the raw pokemon and its position in the file.
+// migrations[N] is applied to a version N file.
+var migrations = map[int]func(p map[string]any, i int){
+	1: migrateLevels,
+	2: migrateInstanceIDs,
+	3: migrateOutdated,
+	4: keepPokemon,
+}
+
+// migrate applies migrations[version] to the raw contents of a file and
+// returns them as version+1.
+func migrate(raw json.RawMessage, version int) (json.RawMessage, error) {
+	var file struct {
+		Pokemons []map[string]any `json:"pokemons"`
+	}
+	if err := json.Unmarshal(raw, &file); err != nil {
+		return nil, err
+	}
+
+	for i, p := range file.Pokemons {
+		migrations[version](p, i)
+	}
+
+	return json.Marshal(map[string]any{
+		"version":  version + 1,
+		"pokemons": file.Pokemons,
+	})
+}
+
+// migrateLevels gives every pokemon of a version 1 file the default level.
+// The growth rate is unknown offline and is filled in on first use.
+func migrateLevels(p map[string]any, _ int) {
+	p["level"] = DefaultLevel
+	p["experience"] = leveling.ExperienceForLevel("", DefaultLevel)
+	p["growth_rate"] = ""
+}
+
+// migrateInstanceIDs numbers the pokemons of a version 2 file, which held
+// one pokemon per species. When and where they were caught is unknown.
+func migrateInstanceIDs(p map[string]any, i int) {
+	p["instance_id"] = i + 1
+}
+
+// migrateOutdated marks the pokemons of a version 3 file as outdated, as they
+// may have been stored before their abilities, forms, sprites and how they
+// learn their moves were. Their details are fetched again when needed.
+func migrateOutdated(p map[string]any, _ int) {
+	p["outdated"] = true
+}
+
+// keepPokemon leaves the pokemons of a version 4 file as they are. Version 4
+// files don't record the next instance ID, it follows the highest one.
+func keepPokemon(map[string]any, int) {}
+
+func DefaultPath() (string, error) {
+	return storage.DataPath("pokedex.json")
+}
+
+func readFile(path string) (pokedexFile, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return pokedexFile{}, err
+	}
+
+	return decode(data)
+}
+
+func decode(data []byte) (pokedexFile, error) {
+	var header versionHeader
+	if err := json.Unmarshal(data, &header); err != nil {
+		return pokedexFile{}, fmt.Errorf("%w: %v", ErrCorruptFile, err)
+	}
+
+	if header.Version < 1 || header.Version > fileVersion {
+		return pokedexFile{}, fmt.Errorf("%w: got version %d, this build reads up to %d", ErrUnsupportedVersion, header.Version, fileVersion)
+	}
+
+	raw := json.RawMessage(data)
+	for v := header.Version; v < fileVersion; v++ {
+		if _, ok := migrations[v]; !ok {
+			return pokedexFile{}, fmt.Errorf("%w: no migration from version %d", ErrUnsupportedVersion, v)
+		}
+
+		migrated, err := migrate(raw, v)
+		if err != nil {
+			return pokedexFile{}, fmt.Errorf("%w: migrating from version %d: %v", ErrCorruptFile, v, err)
+		}
+		raw = migrated
+	}
+
+	var file pokedexFile
+	if err := json.Unmarshal(raw, &file); err != nil {
+		return pokedexFile{}, fmt.Errorf("%w: %v", ErrCorruptFile, err)
+	}
+
+	return file, nil
+}
+
+func writeFile(path string, nextID int, pokemons []models.CaughtPokemon) error {
+	data, err := json.MarshalIndent(pokedexFile{
+		Version:  fileVersion,
+		NextID:   nextID,
+		Pokemons: pokemons,
+	}, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	return storage.WriteFileAtomic(path, data)
+}
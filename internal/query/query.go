@@ -0,0 +1,230 @@
+// Package query filters and sorts pokemons by their fields.
+package query
+
+import (
+	"cmp"
+	"errors"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/NeriusZar/pokedexcli/internal/models"
+)
+
+var ErrInvalid = errors.New("invalid query")
+
+// Stats are the base stats that can be queried like any other field.
+var Stats = []string{"hp", "attack", "defense", "special-attack", "special-defense", "speed"}
+
+// textFields are compared as text, every other field as a number.
+var textFields = []string{"name", "type"}
+
+var numberFields = []string{"id", "weight", "height", "base-experience"}
+
+// operators are tried in order, so longer ones come first.
+var operators = []string{">=", "<=", "!=", "=", "<", ">", "~"}
+
+// Condition compares a field of a pokemon with a value. "~" tells whether a
+// text field contains the value.
+type Condition struct {
+	Field string
+	Op    string
+	Value string
+}
+
+// ParseCondition reads a condition written as field, operator and value,
+// e.g. "hp>=50", "type=water" or "name~char".
+func ParseCondition(s string) (Condition, error) {
+	i := strings.IndexAny(s, "<>=!~")
+	if i <= 0 {
+		return Condition{}, fmt.Errorf("%w: %q is not a condition like hp>=50", ErrInvalid, s)
+	}
+
+	for _, op := range operators {
+		if !strings.HasPrefix(s[i:], op) {
+			continue
+		}
+
+		c := Condition{Field: s[:i], Op: op, Value: s[i+len(op):]}
+		return c, c.validate()
+	}
+
+	return Condition{}, fmt.Errorf("%w: unknown operator in %q", ErrInvalid, s)
+}
+
+func (c Condition) validate() error {
+	if c.Value == "" {
+		return fmt.Errorf("%w: %s%s needs a value", ErrInvalid, c.Field, c.Op)
+	}
+
+	if slices.Contains(textFields, c.Field) {
+		if c.Op != "=" && c.Op != "!=" && c.Op != "~" {
+			return fmt.Errorf("%w: %s can only be compared with =, != or ~", ErrInvalid, c.Field)
+		}
+		return nil
+	}
+
+	if !isNumberField(c.Field) {
+		return fmt.Errorf("%w: unknown field %s", ErrInvalid, c.Field)
+	}
+	if c.Op == "~" {
+		return fmt.Errorf("%w: %s is a number and can't be searched", ErrInvalid, c.Field)
+	}
+	if _, err := strconv.Atoi(c.Value); err != nil {
+		return fmt.Errorf("%w: %s is compared with a number, got %s", ErrInvalid, c.Field, c.Value)
+	}
+
+	return nil
+}
+
+func (c Condition) Match(p models.Pokemon) bool {
+	switch c.Field {
+	case "name":
+		return matchText(p.Name, c.Op, c.Value)
+	case "type":
+		// A pokemon is not of a type when none of its types is.
+		op := c.Op
+		if op == "!=" {
+			op = "="
+		}
+		matches := slices.ContainsFunc(p.Types, func(t string) bool { return matchText(t, op, c.Value) })
+		return matches != (c.Op == "!=")
+	}
+
+	actual, ok := number(p, c.Field)
+	if !ok {
+		return false
+	}
+	value, _ := strconv.Atoi(c.Value)
+
+	switch c.Op {
+	case "=":
+		return actual == value
+	case "!=":
+		return actual != value
+	case "<":
+		return actual < value
+	case "<=":
+		return actual <= value
+	case ">":
+		return actual > value
+	case ">=":
+		return actual >= value
+	}
+
+	return false
+}
+
+func (c Condition) String() string {
+	return c.Field + c.Op + c.Value
+}
+
+func matchText(actual, op, value string) bool {
+	switch op {
+	case "=":
+		return actual == value
+	case "!=":
+		return actual != value
+	case "~":
+		return strings.Contains(actual, value)
+	}
+
+	return false
+}
+
+// Query selects the pokemons matching every condition, ordered by Sort: a
+// field name, prefixed with "-" for descending order. Pokemons are ordered by
+// national dex ID by default.
+type Query struct {
+	Conditions []Condition
+	Sort       string
+}
+
+// ValidateSort reports whether pokemons can be sorted by sort.
+func ValidateSort(sort string) error {
+	field := strings.TrimPrefix(sort, "-")
+	if field == "name" || isNumberField(field) {
+		return nil
+	}
+
+	return fmt.Errorf("%w: can't sort by %s", ErrInvalid, field)
+}
+
+func (q Query) Match(p models.Pokemon) bool {
+	for _, c := range q.Conditions {
+		if !c.Match(p) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// Apply returns the items whose pokemon matches the query, in the query's
+// order. Items that compare equal keep their order.
+func Apply[T any](q Query, items []T, pokemon func(T) models.Pokemon) []T {
+	result := []T{}
+	for _, item := range items {
+		if q.Match(pokemon(item)) {
+			result = append(result, item)
+		}
+	}
+
+	field, descending := strings.CutPrefix(q.Sort, "-")
+	if field == "" {
+		field = "id"
+	}
+
+	slices.SortStableFunc(result, func(a, b T) int {
+		order := compare(pokemon(a), pokemon(b), field)
+		if descending {
+			return -order
+		}
+		return order
+	})
+
+	return result
+}
+
+func compare(a, b models.Pokemon, field string) int {
+	if field == "name" {
+		return cmp.Compare(a.Name, b.Name)
+	}
+
+	x, _ := number(a, field)
+	y, _ := number(b, field)
+	return cmp.Compare(x, y)
+}
+
+// NumberFields returns the fields compared as numbers, stats included.
+func NumberFields() []string {
+	return slices.Concat(numberFields, Stats)
+}
+
+func isNumberField(field string) bool {
+	return slices.Contains(numberFields, field) || slices.Contains(Stats, field)
+}
+
+// number returns the value of a numeric field of p, and false when p has no
+// such stat.
+func number(p models.Pokemon, field string) (int, bool) {
+	switch field {
+	case "id":
+		return p.ID, true
+	case "weight":
+		return p.Weight, true
+	case "height":
+		return p.Height, true
+	case "base-experience":
+		return p.BaseExperience, true
+	}
+
+	for _, stat := range p.Stats {
+		if stat.Name == field {
+			return stat.BaseStat, true
+		}
+	}
+
+	return 0, false
+}
@@ -0,0 +1,113 @@
+package query
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/NeriusZar/pokedexcli/internal/models"
+)
+
+func pokemon(name string, id, weight, hp int, types ...string) models.Pokemon {
+	return models.Pokemon{
+		Name:   name,
+		ID:     id,
+		Weight: weight,
+		Types:  types,
+		Stats:  []models.PokemonStat{{Name: "hp", BaseStat: hp}},
+	}
+}
+
+var pokemons = []models.Pokemon{
+	pokemon("squirtle", 7, 90, 44, "water"),
+	pokemon("charmander", 4, 85, 39, "fire"),
+	pokemon("tentacool", 72, 455, 40, "water", "poison"),
+	pokemon("charizard", 6, 905, 78, "fire", "flying"),
+	pokemon("bulbasaur", 1, 69, 45, "grass", "poison"),
+}
+
+func TestParseCondition(t *testing.T) {
+	cases := []struct {
+		input    string
+		expected Condition
+		wantErr  bool
+	}{
+		{input: "hp>=50", expected: Condition{"hp", ">=", "50"}},
+		{input: "type=water", expected: Condition{"type", "=", "water"}},
+		{input: "name~char", expected: Condition{"name", "~", "char"}},
+		{input: "weight!=10", expected: Condition{"weight", "!=", "10"}},
+		{input: "special-attack<60", expected: Condition{"special-attack", "<", "60"}},
+		{input: "hp", wantErr: true},
+		{input: "=water", wantErr: true},
+		{input: "hp>=", wantErr: true},
+		{input: "hp>=many", wantErr: true},
+		{input: "name>char", wantErr: true},
+		{input: "weight~10", wantErr: true},
+		{input: "color=red", wantErr: true},
+	}
+
+	for _, c := range cases {
+		actual, err := ParseCondition(c.input)
+		if c.wantErr {
+			if !errors.Is(err, ErrInvalid) {
+				t.Errorf("%s: expected ErrInvalid, got %v", c.input, err)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%s: unexpected error: %v", c.input, err)
+			continue
+		}
+		if actual != c.expected {
+			t.Errorf("%s: expected %+v, got %+v", c.input, c.expected, actual)
+		}
+	}
+}
+
+func TestApply(t *testing.T) {
+	cases := []struct {
+		name       string
+		conditions []string
+		sort       string
+		expected   []string
+	}{
+		{"national dex order by default", nil, "", []string{"bulbasaur", "charmander", "charizard", "squirtle", "tentacool"}},
+		{"by name", nil, "name", []string{"bulbasaur", "charizard", "charmander", "squirtle", "tentacool"}},
+		{"heaviest first", nil, "-weight", []string{"charizard", "tentacool", "squirtle", "charmander", "bulbasaur"}},
+		{"by stat", []string{"hp<45"}, "hp", []string{"charmander", "tentacool", "squirtle"}},
+		{"by type", []string{"type=water"}, "", []string{"squirtle", "tentacool"}},
+		{"excluding a type", []string{"type!=poison"}, "", []string{"charmander", "charizard", "squirtle"}},
+		{"search and minimum", []string{"name~char", "hp>=50"}, "", []string{"charizard"}},
+		{"no match", []string{"weight>1000"}, "", []string{}},
+	}
+
+	for _, c := range cases {
+		q := Query{Sort: c.sort}
+		for _, s := range c.conditions {
+			condition, err := ParseCondition(s)
+			if err != nil {
+				t.Fatal(err)
+			}
+			q.Conditions = append(q.Conditions, condition)
+		}
+
+		actual := []string{}
+		for _, p := range Apply(q, pokemons, func(p models.Pokemon) models.Pokemon { return p }) {
+			actual = append(actual, p.Name)
+		}
+		if !reflect.DeepEqual(actual, c.expected) {
+			t.Errorf("%s: expected %v, got %v", c.name, c.expected, actual)
+		}
+	}
+}
+
+func TestValidateSort(t *testing.T) {
+	for _, sort := range []string{"id", "name", "-weight", "height", "speed"} {
+		if err := ValidateSort(sort); err != nil {
+			t.Errorf("%s: unexpected error: %v", sort, err)
+		}
+	}
+	if err := ValidateSort("type"); !errors.Is(err, ErrInvalid) {
+		t.Errorf("expected ErrInvalid for sorting by type, got %v", err)
+	}
+}
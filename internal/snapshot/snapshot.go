@@ -0,0 +1,88 @@
+package snapshot
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/NeriusZar/pokedexcli/internal/storage"
+)
+
+// Store is a directory of recorded PokeAPI responses. Every response is kept
+// in a file named after the path and query of the URL it was fetched from, so
+// a snapshot can be replayed against any host.
+type Store struct {
+	dir string
+}
+
+type NotInSnapshotError struct {
+	URL string
+}
+
+func (e *NotInSnapshotError) Error() string {
+	return fmt.Sprintf("%s is not in the offline snapshot", e.URL)
+}
+
+func NewStore(dir string) Store {
+	return Store{dir: dir}
+}
+
+func DefaultDir() (string, error) {
+	return storage.DataPath("snapshot")
+}
+
+func (s Store) Dir() string {
+	return s.dir
+}
+
+func (s Store) Get(rawUrl string) ([]byte, error) {
+	path, err := s.path(rawUrl)
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, &NotInSnapshotError{URL: rawUrl}
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return data, nil
+}
+
+func (s Store) Put(rawUrl string, data []byte) error {
+	path, err := s.path(rawUrl)
+	if err != nil {
+		return err
+	}
+
+	return storage.WriteFileAtomic(path, data)
+}
+
+func (s Store) path(rawUrl string) (string, error) {
+	u, err := url.Parse(rawUrl)
+	if err != nil {
+		return "", err
+	}
+
+	name := strings.Trim(u.Path, "/")
+	if name == "" {
+		return "", fmt.Errorf("cannot store %s in a snapshot", rawUrl)
+	}
+	if query := u.Query(); len(query) > 0 {
+		name += "@" + query.Encode()
+	}
+	// Names like ../../tmp/x would reach outside of the snapshot.
+	name = filepath.FromSlash(name)
+	if !filepath.IsLocal(name) {
+		return "", fmt.Errorf("cannot store %s in a snapshot", rawUrl)
+	}
+
+	return filepath.Join(s.dir, name+".json"), nil
+}
@@ -0,0 +1,65 @@
+package snapshot
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestPutGet(t *testing.T) {
+	cases := []struct {
+		put string
+		get string
+	}{
+		{
+			put: "https://pokeapi.co/api/v2/pokemon/pikachu",
+			get: "https://pokeapi.co/api/v2/pokemon/pikachu",
+		},
+		{
+			put: "https://pokeapi.co/api/v2/location-area?offset=20&limit=20",
+			get: "http://127.0.0.1:8080/api/v2/location-area?limit=20&offset=20",
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.put, func(t *testing.T) {
+			store := NewStore(t.TempDir())
+			if err := store.Put(c.put, []byte("testdata")); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			data, err := store.Get(c.get)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if string(data) != "testdata" {
+				t.Errorf("expected to find value")
+			}
+		})
+	}
+}
+
+func TestGetMissing(t *testing.T) {
+	store := NewStore(t.TempDir())
+
+	_, err := store.Get("https://pokeapi.co/api/v2/pokemon/mew")
+
+	var notInSnapshot *NotInSnapshotError
+	if !errors.As(err, &notInSnapshot) {
+		t.Fatalf("expected NotInSnapshotError, got %v", err)
+	}
+	if notInSnapshot.URL != "https://pokeapi.co/api/v2/pokemon/mew" {
+		t.Errorf("unexpected url in error: %s", notInSnapshot.URL)
+	}
+}
+
+func TestPathsStayInTheSnapshot(t *testing.T) {
+	store := NewStore(t.TempDir())
+
+	rawUrl := "https://pokeapi.co/api/v2/location-area/../../../../../tmp/escaped"
+	if err := store.Put(rawUrl, []byte("testdata")); err == nil {
+		t.Errorf("expected storing %s to fail", rawUrl)
+	}
+	if _, err := store.Get(rawUrl); err == nil {
+		t.Errorf("expected reading %s to fail", rawUrl)
+	}
+}
@@ -0,0 +1,153 @@
+// Package sprite draws pokemon sprites in the terminal.
+package sprite
+
+import (
+	"fmt"
+	"image"
+	"image/color"
+	"strings"
+)
+
+// Mode is how sprites are coloured in the terminal.
+type Mode string
+
+const (
+	TrueColor Mode = "truecolor"
+	Color256  Mode = "256"
+	ASCII     Mode = "ascii"
+	// Auto picks the mode from the environment of the terminal.
+	Auto Mode = "auto"
+)
+
+func ParseMode(s string) (Mode, error) {
+	switch m := Mode(s); m {
+	case TrueColor, Color256, ASCII, Auto:
+		return m, nil
+	default:
+		return "", fmt.Errorf("unknown colour mode %q, expected auto, truecolor, 256 or ascii", s)
+	}
+}
+
+// DetectMode picks the richest mode the terminal described by getenv
+// supports. Output that doesn't go to a terminal is never coloured.
+func DetectMode(terminal bool, getenv func(string) string) Mode {
+	if !terminal || getenv("NO_COLOR") != "" || getenv("TERM") == "" || getenv("TERM") == "dumb" {
+		return ASCII
+	}
+	switch getenv("COLORTERM") {
+	case "truecolor", "24bit":
+		return TrueColor
+	}
+
+	return Color256
+}
+
+// asciiRamp goes from the darkest to the lightest character.
+const asciiRamp = "@%#*+=-:."
+
+const reset = "\x1b[0m"
+
+// Render draws img with one character for every two rows of pixels, the top
+// one in the foreground of a half block and the bottom one in its
+// background. Transparent borders are cropped. In ASCII mode every character
+// is picked by how light its two pixels are.
+func Render(img image.Image, mode Mode) string {
+	bounds := opaqueBounds(img)
+
+	var b strings.Builder
+	for y := bounds.Min.Y; y < bounds.Max.Y; y += 2 {
+		var line strings.Builder
+		for x := bounds.Min.X; x < bounds.Max.X; x++ {
+			top, topOk := pixel(img, x, y)
+			var bottom color.RGBA
+			var bottomOk bool
+			if y+1 < bounds.Max.Y {
+				bottom, bottomOk = pixel(img, x, y+1)
+			}
+
+			line.WriteString(cell(mode, top, topOk, bottom, bottomOk))
+		}
+		b.WriteString(strings.TrimRight(line.String(), " "))
+		b.WriteString("\n")
+	}
+
+	return b.String()
+}
+
+func cell(mode Mode, top color.RGBA, topOk bool, bottom color.RGBA, bottomOk bool) string {
+	switch {
+	case !topOk && !bottomOk:
+		return " "
+	case mode == ASCII:
+		return string(asciiRamp[shade(top, topOk, bottom, bottomOk)])
+	case topOk && bottomOk:
+		return foreground(mode, top) + background(mode, bottom) + "▀" + reset
+	case topOk:
+		return foreground(mode, top) + "▀" + reset
+	default:
+		return foreground(mode, bottom) + "▄" + reset
+	}
+}
+
+// shade is the index in asciiRamp of the average lightness of the opaque
+// pixels of a cell.
+func shade(top color.RGBA, topOk bool, bottom color.RGBA, bottomOk bool) int {
+	var sum, n float64
+	for _, p := range []struct {
+		c  color.RGBA
+		ok bool
+	}{{top, topOk}, {bottom, bottomOk}} {
+		if p.ok {
+			sum += (0.299*float64(p.c.R) + 0.587*float64(p.c.G) + 0.114*float64(p.c.B)) / 255
+			n++
+		}
+	}
+
+	return min(len(asciiRamp)-1, int(sum/n*float64(len(asciiRamp))))
+}
+
+func foreground(mode Mode, c color.RGBA) string {
+	if mode == TrueColor {
+		return fmt.Sprintf("\x1b[38;2;%d;%d;%dm", c.R, c.G, c.B)
+	}
+	return fmt.Sprintf("\x1b[38;5;%dm", palette256(c))
+}
+
+func background(mode Mode, c color.RGBA) string {
+	if mode == TrueColor {
+		return fmt.Sprintf("\x1b[48;2;%d;%d;%dm", c.R, c.G, c.B)
+	}
+	return fmt.Sprintf("\x1b[48;5;%dm", palette256(c))
+}
+
+// palette256 is the closest colour of the 6x6x6 cube of the 256-colour
+// palette.
+func palette256(c color.RGBA) int {
+	level := func(v uint8) int {
+		return (int(v)*5 + 127) / 255
+	}
+
+	return 16 + 36*level(c.R) + 6*level(c.G) + level(c.B)
+}
+
+// pixel returns the colour at x, y and whether it is opaque enough to draw.
+func pixel(img image.Image, x, y int) (color.RGBA, bool) {
+	c := color.NRGBAModel.Convert(img.At(x, y)).(color.NRGBA)
+
+	return color.RGBA{R: c.R, G: c.G, B: c.B, A: 255}, c.A >= 128
+}
+
+// opaqueBounds is the smallest rectangle holding every opaque pixel of img.
+func opaqueBounds(img image.Image) image.Rectangle {
+	var bounds image.Rectangle
+	b := img.Bounds()
+	for y := b.Min.Y; y < b.Max.Y; y++ {
+		for x := b.Min.X; x < b.Max.X; x++ {
+			if _, ok := pixel(img, x, y); ok {
+				bounds = bounds.Union(image.Rect(x, y, x+1, y+1))
+			}
+		}
+	}
+
+	return bounds
+}
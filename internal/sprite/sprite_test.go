@@ -0,0 +1,77 @@
+package sprite
+
+import (
+	"image"
+	"image/color"
+	"testing"
+)
+
+var (
+	red   = color.NRGBA{R: 255, A: 255}
+	blue  = color.NRGBA{B: 255, A: 255}
+	white = color.NRGBA{R: 255, G: 255, B: 255, A: 255}
+)
+
+// testImage is a 2x3 sprite with a transparent border: red over blue, then
+// white on the left of the last row.
+func testImage() image.Image {
+	img := image.NewNRGBA(image.Rect(0, 0, 4, 5))
+	img.Set(1, 1, red)
+	img.Set(2, 1, red)
+	img.Set(1, 2, blue)
+	img.Set(2, 2, blue)
+	img.Set(1, 3, white)
+
+	return img
+}
+
+func TestRender(t *testing.T) {
+	cases := []struct {
+		mode     Mode
+		expected string
+	}{
+		{
+			mode: TrueColor,
+			expected: "\x1b[38;2;255;0;0m\x1b[48;2;0;0;255m▀\x1b[0m\x1b[38;2;255;0;0m\x1b[48;2;0;0;255m▀\x1b[0m\n" +
+				"\x1b[38;2;255;255;255m▀\x1b[0m\n",
+		},
+		{
+			mode: Color256,
+			expected: "\x1b[38;5;196m\x1b[48;5;21m▀\x1b[0m\x1b[38;5;196m\x1b[48;5;21m▀\x1b[0m\n" +
+				"\x1b[38;5;231m▀\x1b[0m\n",
+		},
+		{
+			mode:     ASCII,
+			expected: "%%\n.\n",
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(string(c.mode), func(t *testing.T) {
+			if actual := Render(testImage(), c.mode); actual != c.expected {
+				t.Errorf("unexpected sprite:\nexpected: %q\nactual:   %q", c.expected, actual)
+			}
+		})
+	}
+}
+
+func TestDetectMode(t *testing.T) {
+	cases := []struct {
+		env      map[string]string
+		piped    bool
+		expected Mode
+	}{
+		{env: map[string]string{"TERM": "xterm-256color", "COLORTERM": "truecolor"}, expected: TrueColor},
+		{env: map[string]string{"TERM": "xterm-256color"}, expected: Color256},
+		{env: map[string]string{"TERM": "xterm-256color", "NO_COLOR": "1"}, expected: ASCII},
+		{env: map[string]string{"TERM": "dumb"}, expected: ASCII},
+		{env: map[string]string{}, expected: ASCII},
+		{env: map[string]string{"TERM": "xterm-256color", "COLORTERM": "truecolor"}, piped: true, expected: ASCII},
+	}
+
+	for _, c := range cases {
+		if actual := DetectMode(!c.piped, func(key string) string { return c.env[key] }); actual != c.expected {
+			t.Errorf("expected %s for %v (piped: %v), got %s", c.expected, c.env, c.piped, actual)
+		}
+	}
+}
@@ -0,0 +1,28 @@
+package state
+
+// Count returns how many of item are in the bag.
+func (s *State) Count(item string) int {
+	return s.Inventory[item]
+}
+
+// AddItem puts count of item in the bag.
+func (s *State) AddItem(item string, count int) {
+	if s.Inventory == nil {
+		s.Inventory = map[string]int{}
+	}
+	s.Inventory[item] += count
+}
+
+// TakeItem removes one item from the bag, reporting false when there is none.
+func (s *State) TakeItem(item string) bool {
+	if s.Inventory[item] <= 0 {
+		return false
+	}
+
+	s.Inventory[item]--
+	if s.Inventory[item] == 0 {
+		delete(s.Inventory, item)
+	}
+
+	return true
+}
@@ -0,0 +1,25 @@
+package state
+
+import "slices"
+
+// MarkSeen records the pokemons as seen, reporting whether any of them was
+// new.
+func (s *State) MarkSeen(pokemons ...string) bool {
+	added := false
+	for _, p := range pokemons {
+		i, found := slices.BinarySearch(s.Seen, p)
+		if found {
+			continue
+		}
+
+		s.Seen = slices.Insert(s.Seen, i, p)
+		added = true
+	}
+
+	return added
+}
+
+func (s *State) HasSeen(pokemon string) bool {
+	_, found := slices.BinarySearch(s.Seen, pokemon)
+	return found
+}
@@ -0,0 +1,83 @@
+package state
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"maps"
+	"os"
+
+	"github.com/NeriusZar/pokedexcli/internal/storage"
+)
+
+const fileVersion = 2
+
+// StartingMoney is the money of a new player.
+const StartingMoney = 3000
+
+// startingItems is the bag of a new player.
+var startingItems = map[string]int{"poke-ball": 10}
+
+var ErrUnsupportedVersion = errors.New("state file version is not supported")
+
+// State is everything about the player, other than the Pokedex, that is kept
+// between sessions.
+type State struct {
+	Version   int            `json:"version"`
+	Location  string         `json:"location,omitempty"`
+	Money     int            `json:"money"`
+	Inventory map[string]int `json:"inventory"`
+	// Seen lists the pokemons the player came across, sorted by name.
+	Seen []string `json:"seen,omitempty"`
+}
+
+// New returns the state of a new player.
+func New() State {
+	return State{Version: fileVersion, Money: StartingMoney, Inventory: maps.Clone(startingItems)}
+}
+
+func DefaultPath() (string, error) {
+	return storage.DataPath("state.json")
+}
+
+// Load reads the state stored at path. A missing file is a fresh state.
+func Load(path string) (State, error) {
+	data, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return New(), nil
+	}
+	if err != nil {
+		return State{}, err
+	}
+
+	var s State
+	if err := json.Unmarshal(data, &s); err != nil {
+		return State{}, fmt.Errorf("state file %s is corrupt: %w", path, err)
+	}
+	if s.Version < 1 || s.Version > fileVersion {
+		return State{}, fmt.Errorf("%w: got version %d in %s", ErrUnsupportedVersion, s.Version, path)
+	}
+
+	// Version 1 had no inventory, players get the starting bag.
+	if s.Version == 1 {
+		s.Money = StartingMoney
+		s.Inventory = maps.Clone(startingItems)
+	}
+	if s.Inventory == nil {
+		s.Inventory = map[string]int{}
+	}
+
+	return s, nil
+}
+
+func (s State) Save(path string) error {
+	s.Version = fileVersion
+
+	data, err := json.MarshalIndent(s, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	return storage.WriteFileAtomic(path, data)
+}
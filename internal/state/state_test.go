@@ -0,0 +1,98 @@
+package state
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+)
+
+func TestSaveLoad(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "state.json")
+
+	fresh, err := Load(path)
+	if err != nil {
+		t.Fatalf("unexpected error loading a missing file: %v", err)
+	}
+	if fresh.Location != "" {
+		t.Errorf("expected no location in a fresh state")
+	}
+
+	fresh.Location = "canalave-city-area"
+	if err := fresh.Save(path); err != nil {
+		t.Fatalf("unexpected error saving: %v", err)
+	}
+
+	loaded, err := Load(path)
+	if err != nil {
+		t.Fatalf("unexpected error loading: %v", err)
+	}
+	if loaded.Location != "canalave-city-area" {
+		t.Errorf("expected the location to be kept, got %q", loaded.Location)
+	}
+}
+
+func TestLoadRejectsNewerVersions(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "state.json")
+	if err := os.WriteFile(path, []byte(`{"version": 99}`), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := Load(path); !errors.Is(err, ErrUnsupportedVersion) {
+		t.Errorf("expected ErrUnsupportedVersion, got %v", err)
+	}
+}
+
+func TestLoadMigratesVersion1(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "state.json")
+	if err := os.WriteFile(path, []byte(`{"version": 1, "location": "canalave-city-area"}`), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	s, err := Load(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if s.Location != "canalave-city-area" {
+		t.Errorf("expected the location to be kept, got %q", s.Location)
+	}
+	if s.Money != StartingMoney || s.Count("poke-ball") != 10 {
+		t.Errorf("expected the starting bag, got %d money and %v", s.Money, s.Inventory)
+	}
+}
+
+func TestInventory(t *testing.T) {
+	s := New()
+
+	s.AddItem("great-ball", 2)
+	if !s.TakeItem("great-ball") || s.Count("great-ball") != 1 {
+		t.Errorf("expected one great ball left, got %d", s.Count("great-ball"))
+	}
+	if !s.TakeItem("great-ball") {
+		t.Error("expected to take the last great ball")
+	}
+	if s.TakeItem("great-ball") {
+		t.Error("expected the bag to be out of great balls")
+	}
+	if _, ok := s.Inventory["great-ball"]; ok {
+		t.Error("expected used up items to leave the bag")
+	}
+}
+
+func TestMarkSeen(t *testing.T) {
+	s := New()
+
+	if !s.MarkSeen("pidgey", "caterpie") {
+		t.Error("expected new pokemons to be reported")
+	}
+	if s.MarkSeen("caterpie") {
+		t.Error("expected a pokemon seen before not to be reported")
+	}
+	if !s.HasSeen("pidgey") || s.HasSeen("pikachu") {
+		t.Errorf("unexpected seen pokemons %v", s.Seen)
+	}
+	if !slices.Equal(s.Seen, []string{"caterpie", "pidgey"}) {
+		t.Errorf("expected seen pokemons to be sorted, got %v", s.Seen)
+	}
+}
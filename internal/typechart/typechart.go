@@ -0,0 +1,91 @@
+package typechart
+
+import "github.com/NeriusZar/pokedexcli/internal/models"
+
+// Chart maps an attacking type to the damage multipliers it deals to each
+// defending type. Pairs that are not listed deal regular damage.
+type Chart map[string]map[string]float64
+
+func (c Chart) Multiplier(attacking string, defending []string) float64 {
+	multiplier := 1.0
+	for _, d := range defending {
+		if m, ok := c[attacking][d]; ok {
+			multiplier *= m
+		}
+	}
+
+	return multiplier
+}
+
+// FromTypes builds the part of the chart described by the damage relations of
+// types: how they fare when attacking and when being attacked.
+func FromTypes(types []models.Type) Chart {
+	c := Chart{}
+	set := func(attacking, defending string, multiplier float64) {
+		if c[attacking] == nil {
+			c[attacking] = map[string]float64{}
+		}
+		c[attacking][defending] = multiplier
+	}
+
+	for _, t := range types {
+		r := t.DamageRelations
+		for _, d := range r.DoubleDamageTo {
+			set(t.Name, d, 2)
+		}
+		for _, d := range r.HalfDamageTo {
+			set(t.Name, d, 0.5)
+		}
+		for _, d := range r.NoDamageTo {
+			set(t.Name, d, 0)
+		}
+		for _, a := range r.DoubleDamageFrom {
+			set(a, t.Name, 2)
+		}
+		for _, a := range r.HalfDamageFrom {
+			set(a, t.Name, 0.5)
+		}
+		for _, a := range r.NoDamageFrom {
+			set(a, t.Name, 0)
+		}
+	}
+
+	return c
+}
+
+// Defending returns the multiplier of every attacking type in the chart that
+// does not deal regular damage to a pokemon of the defending types.
+func (c Chart) Defending(defending []string) map[string]float64 {
+	multipliers := map[string]float64{}
+	for attacking := range c {
+		if m := c.Multiplier(attacking, defending); m != 1 {
+			multipliers[attacking] = m
+		}
+	}
+
+	return multipliers
+}
+
+// Default returns the type chart used since generation VI.
+func Default() Chart {
+	return Chart{
+		"normal":   {"rock": 0.5, "ghost": 0, "steel": 0.5},
+		"fire":     {"fire": 0.5, "water": 0.5, "grass": 2, "ice": 2, "bug": 2, "rock": 0.5, "dragon": 0.5, "steel": 2},
+		"water":    {"fire": 2, "water": 0.5, "grass": 0.5, "ground": 2, "rock": 2, "dragon": 0.5},
+		"electric": {"water": 2, "electric": 0.5, "grass": 0.5, "ground": 0, "flying": 2, "dragon": 0.5},
+		"grass":    {"fire": 0.5, "water": 2, "grass": 0.5, "poison": 0.5, "ground": 2, "flying": 0.5, "bug": 0.5, "rock": 2, "dragon": 0.5, "steel": 0.5},
+		"ice":      {"fire": 0.5, "water": 0.5, "grass": 2, "ice": 0.5, "ground": 2, "flying": 2, "dragon": 2, "steel": 0.5},
+		"fighting": {"normal": 2, "ice": 2, "poison": 0.5, "flying": 0.5, "psychic": 0.5, "bug": 0.5, "rock": 2, "ghost": 0, "dark": 2, "steel": 2, "fairy": 0.5},
+		"poison":   {"grass": 2, "poison": 0.5, "ground": 0.5, "rock": 0.5, "ghost": 0.5, "steel": 0, "fairy": 2},
+		"ground":   {"fire": 2, "electric": 2, "grass": 0.5, "poison": 2, "flying": 0, "bug": 0.5, "rock": 2, "steel": 2},
+		"flying":   {"electric": 0.5, "grass": 2, "fighting": 2, "bug": 2, "rock": 0.5, "steel": 0.5},
+		"psychic":  {"fighting": 2, "poison": 2, "psychic": 0.5, "dark": 0, "steel": 0.5},
+		"bug":      {"fire": 0.5, "grass": 2, "fighting": 0.5, "poison": 0.5, "flying": 0.5, "psychic": 2, "ghost": 0.5, "dark": 2, "steel": 0.5, "fairy": 0.5},
+		"rock":     {"fire": 2, "ice": 2, "fighting": 0.5, "ground": 0.5, "flying": 2, "bug": 2, "steel": 0.5},
+		"ghost":    {"normal": 0, "psychic": 2, "ghost": 2, "dark": 0.5},
+		"dragon":   {"dragon": 2, "steel": 0.5, "fairy": 0},
+		"dark":     {"fighting": 0.5, "psychic": 2, "ghost": 2, "dark": 0.5, "fairy": 0.5},
+		"steel":    {"fire": 0.5, "water": 0.5, "electric": 0.5, "ice": 2, "rock": 2, "steel": 0.5, "fairy": 2},
+		"fairy":    {"fire": 0.5, "fighting": 2, "poison": 0.5, "dragon": 2, "dark": 2, "steel": 0.5},
+	}
+}
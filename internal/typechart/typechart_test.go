@@ -0,0 +1,73 @@
+package typechart
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/NeriusZar/pokedexcli/internal/models"
+)
+
+func TestMultiplier(t *testing.T) {
+	cases := []struct {
+		attacking string
+		defending []string
+		expected  float64
+	}{
+		{"electric", []string{"water"}, 2},
+		{"electric", []string{"water", "flying"}, 4},
+		{"electric", []string{"ground", "flying"}, 0},
+		{"grass", []string{"water", "poison"}, 1},
+		{"fire", []string{"water", "rock"}, 0.25},
+		{"normal", []string{"normal"}, 1},
+		{"shadow", []string{"normal"}, 1},
+	}
+
+	chart := Default()
+	for _, c := range cases {
+		actual := chart.Multiplier(c.attacking, c.defending)
+		if actual != c.expected {
+			t.Errorf("%s against %v: expected %v, got %v", c.attacking, c.defending, c.expected, actual)
+		}
+	}
+}
+
+func TestDefendingFromTypes(t *testing.T) {
+	normal := models.Type{
+		Name: "normal",
+		DamageRelations: models.DamageRelations{
+			DoubleDamageFrom: []string{"fighting"},
+			NoDamageFrom:     []string{"ghost"},
+			HalfDamageTo:     []string{"rock", "steel"},
+			NoDamageTo:       []string{"ghost"},
+		},
+	}
+	flying := models.Type{
+		Name: "flying",
+		DamageRelations: models.DamageRelations{
+			DoubleDamageFrom: []string{"rock", "electric", "ice"},
+			HalfDamageFrom:   []string{"fighting", "bug", "grass"},
+			NoDamageFrom:     []string{"ground"},
+			DoubleDamageTo:   []string{"fighting", "bug", "grass"},
+		},
+	}
+
+	chart := FromTypes([]models.Type{normal, flying})
+	actual := chart.Defending([]string{"normal", "flying"})
+
+	expected := map[string]float64{
+		"rock":     2,
+		"electric": 2,
+		"ice":      2,
+		"bug":      0.5,
+		"grass":    0.5,
+		"ghost":    0,
+		"ground":   0,
+	}
+	if !reflect.DeepEqual(actual, expected) {
+		t.Errorf("expected %v, got %v", expected, actual)
+	}
+
+	if m := chart.Multiplier("flying", []string{"bug"}); m != 2 {
+		t.Errorf("expected flying to deal x2 to bug, got x%v", m)
+	}
+}
@@ -1,32 +1,87 @@
 package main
 
 import (
-	"bufio"
+	"flag"
 	"fmt"
+	"io"
 	"os"
+	"strings"
+
+	"github.com/NeriusZar/pokedexcli/internal/output"
+	"github.com/NeriusZar/pokedexcli/internal/sprite"
 )
 
 func main() {
-	scanner := bufio.NewScanner(os.Stdin)
-	config := NewConfig()
-	commands := getCommands()
-
-	for {
-		fmt.Print("Pokedex > ")
-		scanner.Scan()
-		input := scanner.Text()
-		cleanedInput := cleanInput(input)
-		if len(cleanedInput) > 0 {
-			command, ok := commands[cleanedInput[0]]
-			if !ok {
-				fmt.Println("Unknown command")
-				continue
-			}
-
-			err := command.callback(&config, cleanedInput[1:]...)
-			if err != nil {
-				fmt.Println("Failed to execute command", err)
-			}
+	var opts options
+	var script, format, colors string
+	flag.BoolVar(&opts.offline, "offline", false, "serve every request from the local snapshot instead of PokeAPI")
+	flag.StringVar(&opts.snapshotDir, "snapshot-dir", "", "directory of the offline snapshot")
+	flag.StringVar(&opts.apiUrl, "api-url", "", "base URL of the PokeAPI to use")
+	flag.StringVar(&format, "output", string(output.Text), "output format: text, json or yaml")
+	flag.Uint64Var(&opts.seed, "seed", 0, "seed for every random outcome, for reproducible sessions")
+	flag.StringVar(&opts.gameVersion, "game", "", "game version wild encounters are rolled for, e.g. red")
+	flag.BoolVar(&opts.strictEncounters, "strict-encounters", false, "only allow catching the pokemon met by the last encounter")
+	flag.StringVar(&colors, "colors", string(sprite.Auto), "colours of sprites: auto, truecolor, 256 or ascii")
+	flag.StringVar(&script, "c", "", "run the given commands, separated by ';', and exit")
+	flag.Usage = func() {
+		fmt.Fprintf(flag.CommandLine.Output(), "Usage:\n  %[1]s [flags]\n  %[1]s [flags] -c \"command; command\"\n  %[1]s [flags] run <script>\n\nFlags:\n", os.Args[0])
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+
+	var err error
+	opts.format, err = output.ParseFormat(format)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(2)
+	}
+	opts.spriteMode, err = sprite.ParseMode(colors)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(2)
+	}
+
+	var in io.Reader = os.Stdin
+	interactive := isTerminal(os.Stdin)
+
+	switch {
+	case script != "":
+		in = strings.NewReader(strings.ReplaceAll(script, ";", "\n"))
+		interactive = false
+	case flag.Arg(0) == "run":
+		if flag.NArg() < 2 {
+			flag.Usage()
+			os.Exit(2)
+		}
+		file, err := os.Open(flag.Arg(1))
+		if err != nil {
+			fmt.Fprintln(os.Stderr, "Failed to open script:", err)
+			os.Exit(1)
 		}
+		defer file.Close()
+		in = file
+		interactive = false
+	case flag.NArg() > 0:
+		flag.Usage()
+		os.Exit(2)
 	}
+
+	config, err := NewConfig(opts)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "Failed to open the Pokedex:", err)
+		os.Exit(1)
+	}
+
+	if !runCommands(&config, in, interactive) {
+		os.Exit(1)
+	}
+}
+
+func isTerminal(f *os.File) bool {
+	info, err := f.Stat()
+	if err != nil {
+		return false
+	}
+
+	return info.Mode()&os.ModeCharDevice != 0
 }
@@ -0,0 +1,94 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/NeriusZar/pokedexcli/internal/api"
+	"github.com/NeriusZar/pokedexcli/internal/output"
+	"github.com/NeriusZar/pokedexcli/internal/pokedex"
+	"github.com/NeriusZar/pokedexcli/internal/snapshot"
+)
+
+// commandError is a failure caused by how a command was used rather than by
+// the PokeAPI or the local files. Its message is shown to the user as is.
+type commandError struct {
+	code    string
+	message string
+}
+
+func (e *commandError) Error() string {
+	return e.message
+}
+
+func usageError(message string) error {
+	return &commandError{code: "invalid_argument", message: message}
+}
+
+// render writes doc in the configured structured format, or calls text to
+// print the human readable version of it.
+func (c *config) render(doc any, text func()) error {
+	if c.format == output.Text || c.format == "" {
+		text()
+		return nil
+	}
+
+	return output.Write(c.out, c.format, doc)
+}
+
+// progress prints a status line that only makes sense to a human reader.
+func (c *config) progress(format string, a ...any) {
+	if c.format == output.Text || c.format == "" {
+		fmt.Fprintf(c.out, format, a...)
+	}
+}
+
+func (c *config) reportError(err error) {
+	var cmdErr *commandError
+	if c.format == output.Text || c.format == "" {
+		if errors.As(err, &cmdErr) {
+			fmt.Fprintln(c.out, cmdErr.message)
+			return
+		}
+		// Like command errors, these tell how the Pokedex was used.
+		if errors.Is(err, pokedex.ErrNotCaught) || errors.Is(err, pokedex.ErrAmbiguous) {
+			fmt.Fprintln(c.out, err)
+			return
+		}
+		fmt.Fprintln(c.out, "Failed to execute command", err)
+		return
+	}
+
+	output.WriteError(c.out, c.format, output.Error{
+		Code:    errorCode(err),
+		Message: err.Error(),
+	})
+}
+
+func errorCode(err error) string {
+	var cmdErr *commandError
+	var notInSnapshot *snapshot.NotInSnapshotError
+	var statusErr *api.StatusError
+
+	switch {
+	case errors.As(err, &cmdErr):
+		return cmdErr.code
+	case errors.As(err, &notInSnapshot):
+		return "not_in_snapshot"
+	case errors.As(err, &statusErr) && statusErr.StatusCode == http.StatusNotFound:
+		return "not_found"
+	case errors.As(err, &statusErr):
+		return "http_error"
+	case errors.Is(err, pokedex.ErrNotCaught):
+		return "not_caught"
+	case errors.Is(err, pokedex.ErrAmbiguous):
+		return "ambiguous"
+	case errors.Is(err, pokedex.ErrCorruptFile):
+		return "corrupt_file"
+	case errors.Is(err, pokedex.ErrUnsupportedVersion):
+		return "unsupported_version"
+	default:
+		return "internal"
+	}
+}
@@ -1,11 +1,78 @@
 package main
 
 import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
 	"strings"
 )
 
+const prompt = "Pokedex > "
+
+// errExit is returned by a command callback to end the session.
+var errExit = errors.New("exit requested")
+
 func cleanInput(text string) []string {
-	text = strings.TrimSpace(text)
 	text = strings.ToLower(text)
-	return strings.Split(text, " ")
+	return strings.Fields(text)
+}
+
+// runCommands executes every line read from in until EOF or the exit command.
+// The prompt is only shown in interactive sessions, and blank lines and lines
+// starting with # are skipped. It returns false if any command failed.
+func runCommands(c *config, in io.Reader, interactive bool) bool {
+	scanner := bufio.NewScanner(in)
+	// Only a person can answer questions. In scripts the next line is a
+	// command, not an answer.
+	if interactive {
+		c.in = scanner
+	}
+	commands := getCommands()
+	ok := true
+
+	for {
+		if interactive {
+			fmt.Fprint(c.out, c.prompt())
+		}
+		if !scanner.Scan() {
+			if interactive {
+				fmt.Fprintln(c.out)
+			}
+			break
+		}
+
+		input := scanner.Text()
+		if strings.HasPrefix(strings.TrimSpace(input), "#") {
+			continue
+		}
+
+		cleanedInput := cleanInput(input)
+		if len(cleanedInput) == 0 {
+			continue
+		}
+
+		command, found := commands[cleanedInput[0]]
+		if !found {
+			c.reportError(&commandError{code: "unknown_command", message: "Unknown command"})
+			ok = false
+			continue
+		}
+
+		err := command.callback(c, cleanedInput[1:]...)
+		if errors.Is(err, errExit) {
+			break
+		}
+		if err != nil {
+			c.reportError(err)
+			ok = false
+		}
+	}
+
+	if err := scanner.Err(); err != nil {
+		c.reportError(fmt.Errorf("failed to read input: %w", err))
+		ok = false
+	}
+
+	return ok
 }
@@ -2,7 +2,10 @@ package main
 
 import (
 	"fmt"
+	"strings"
 	"testing"
+
+	"github.com/NeriusZar/pokedexcli/internal/pokeapitest"
 )
 
 func TestCleanInput(t *testing.T) {
@@ -26,6 +29,14 @@ func TestCleanInput(t *testing.T) {
 			"Hello world",
 			[]string{"hello", "world"},
 		},
+		{
+			"catch  pikachu",
+			[]string{"catch", "pikachu"},
+		},
+		{
+			"   ",
+			[]string{},
+		},
 	}
 
 	for _, c := range testCases {
@@ -71,3 +82,89 @@ func formatSlice(words []string) string {
 
 	return result
 }
+
+func TestRunCommands(t *testing.T) {
+	server := pokeapitest.NewServer()
+	defer server.Close()
+
+	cases := []struct {
+		name        string
+		script      string
+		interactive bool
+		expectedOk  bool
+		expected    string
+	}{
+		{
+			name:       "runs every command until EOF",
+			script:     "# demo\nexplore canalave-city-area\ncatch magikarp master-ball\n\npokedex\n",
+			expectedOk: true,
+			expected:   "Exploring canalave-city-area...\nFound Pokemon:\n - tentacool\n - magikarp\nThrowing a Master Ball at magikarp...\nmagikarp was caught!\nYour Pokedex:\n - magikarp (#1)\n",
+		},
+		{
+			name:       "reports failing commands",
+			script:     "explore nowhere\npokedex",
+			expectedOk: false,
+			expected:   "Exploring nowhere...\n",
+		},
+		{
+			name:       "reports unknown commands",
+			script:     "fly",
+			expectedOk: false,
+			expected:   "Unknown command\n",
+		},
+		{
+			name:       "reports pokemons that were not caught",
+			script:     "inspect pikachu",
+			expectedOk: false,
+			expected:   "you have not caught that pokemon\n",
+		},
+		{
+			name:       "stops at exit",
+			script:     "exit\npokedex",
+			expectedOk: true,
+			expected:   "Closing the Pokedex... Goodbye!\n",
+		},
+		{
+			name:        "asks before releasing",
+			script:      "explore canalave-city-area\ncatch magikarp master-ball\nrelease magikarp\nn\nrelease magikarp\ny\npokedex",
+			interactive: true,
+			expectedOk:  true,
+			expected:    "Pokedex > Exploring canalave-city-area...\nFound Pokemon:\n - tentacool\n - magikarp\nPokedex > Throwing a Master Ball at magikarp...\nmagikarp was caught!\nPokedex > Release magikarp (#1, level 5)? [y/N] magikarp stays with you\nPokedex > Release magikarp (#1, level 5)? [y/N] magikarp was released. Bye, magikarp!\nPokedex > Your Pokedex:\nPokedex > \n",
+		},
+		{
+			name:       "requires --yes to release in scripts",
+			script:     "explore canalave-city-area\ncatch magikarp master-ball\nrelease magikarp\npokedex",
+			expectedOk: false,
+			expected:   "Exploring canalave-city-area...\nFound Pokemon:\n - tentacool\n - magikarp\nThrowing a Master Ball at magikarp...\nmagikarp was caught!\nCan't ask for confirmation here, pass --yes\nYour Pokedex:\n - magikarp (#1)\n",
+		},
+		{
+			name:        "shows the prompt in interactive sessions",
+			script:      "pokedex\n",
+			interactive: true,
+			expectedOk:  true,
+			expected:    "Pokedex > Your Pokedex:\nPokedex > \n",
+		},
+		{
+			name:        "shows the current location in the prompt",
+			script:      "travel canalave-city-area\n",
+			interactive: true,
+			expectedOk:  true,
+			expected:    "Pokedex > You arrived at canalave-city-area\nPokedex (canalave-city-area) > \n",
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			cfg, out := newTestConfig(server)
+
+			ok := runCommands(cfg, strings.NewReader(c.script), c.interactive)
+			if ok != c.expectedOk {
+				t.Errorf("expected ok to be %v", c.expectedOk)
+			}
+
+			if !strings.HasPrefix(out.String(), c.expected) {
+				t.Errorf("unexpected output:\nexpected prefix:\n%s\nactual:\n%s", c.expected, out.String())
+			}
+		})
+	}
+}